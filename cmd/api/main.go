@@ -1,11 +1,14 @@
 package main
 
 import (
+	"time"
+
 	"go.uber.org/fx"
 
 	"github.com/Additional-Code/atlas/internal/app"
 )
 
 func main() {
-	fx.New(app.Module).Run()
+	// Leaves room for HTTP_SHUTDOWN_TIMEOUT plus the remaining stop hooks.
+	fx.New(app.Module, fx.StopTimeout(30*time.Second)).Run()
 }
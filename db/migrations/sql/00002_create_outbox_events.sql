@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_id VARCHAR(128) NOT NULL,
+    sequence BIGINT NOT NULL,
+    topic VARCHAR(255) NOT NULL DEFAULT '',
+    message_key BYTEA NULL,
+    payload BYTEA NOT NULL,
+    headers JSONB NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ NULL,
+    UNIQUE (aggregate_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_status_id ON outbox_events (status, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS settings (
+    key VARCHAR(128) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS settings;
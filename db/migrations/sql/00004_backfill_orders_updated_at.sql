@@ -0,0 +1,6 @@
+-- +goose Up
+UPDATE orders SET updated_at = created_at WHERE updated_at IS NULL;
+ALTER TABLE orders ALTER COLUMN updated_at SET DEFAULT CURRENT_TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE orders ALTER COLUMN updated_at DROP DEFAULT;
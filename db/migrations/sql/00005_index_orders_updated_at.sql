@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at ON orders (updated_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_updated_at;
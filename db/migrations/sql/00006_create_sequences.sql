@@ -0,0 +1,13 @@
+-- +goose Up
+-- Counter table backing named sequences on databases without native sequences
+-- (MySQL, SQLite); Postgres uses a native <name>_seq sequence per name instead.
+CREATE TABLE IF NOT EXISTS sequences (
+    name VARCHAR(64) PRIMARY KEY,
+    value BIGINT NOT NULL
+);
+
+CREATE SEQUENCE IF NOT EXISTS order_number_seq;
+
+-- +goose Down
+DROP SEQUENCE IF EXISTS order_number_seq;
+DROP TABLE IF EXISTS sequences;
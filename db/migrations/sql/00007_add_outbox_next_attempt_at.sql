@@ -0,0 +1,6 @@
+-- +goose Up
+-- Failed publishes wait until next_attempt_at before the relay claims them again.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMPTZ NULL;
+
+-- +goose Down
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS next_attempt_at;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- ListUpdatedSince pages by (updated_at, id), so the index covers ties too.
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at_id ON orders (updated_at, id);
+DROP INDEX IF EXISTS idx_orders_updated_at;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_orders_updated_at ON orders (updated_at);
+DROP INDEX IF EXISTS idx_orders_updated_at_id;
@@ -6,15 +6,27 @@ import (
 	"github.com/Additional-Code/atlas/internal/cache"
 	"github.com/Additional-Code/atlas/internal/config"
 	"github.com/Additional-Code/atlas/internal/database"
+	"github.com/Additional-Code/atlas/internal/health"
 	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/internal/maintenance"
 	"github.com/Additional-Code/atlas/internal/messaging"
 	"github.com/Additional-Code/atlas/internal/observability"
 	repositoryorder "github.com/Additional-Code/atlas/internal/repository/order"
+	repositoryoutbox "github.com/Additional-Code/atlas/internal/repository/outbox"
+	repositorysequence "github.com/Additional-Code/atlas/internal/repository/sequence"
+	repositorysettings "github.com/Additional-Code/atlas/internal/repository/settings"
+	"github.com/Additional-Code/atlas/internal/scheduler"
+	servergrpc "github.com/Additional-Code/atlas/internal/server/grpc"
 	httpserver "github.com/Additional-Code/atlas/internal/server/http"
 	serviceorder "github.com/Additional-Code/atlas/internal/service/order"
+	servicesequence "github.com/Additional-Code/atlas/internal/service/sequence"
+	servicesettings "github.com/Additional-Code/atlas/internal/service/settings"
+	transportgrpc "github.com/Additional-Code/atlas/internal/transport/grpc"
 	transporthttp "github.com/Additional-Code/atlas/internal/transport/http"
 	"github.com/Additional-Code/atlas/internal/worker"
 	workerorder "github.com/Additional-Code/atlas/internal/worker/order"
+	workeroutbox "github.com/Additional-Code/atlas/internal/worker/outbox"
+	workerwebhook "github.com/Additional-Code/atlas/internal/worker/webhook"
 )
 
 // Core provides the foundational modules shared across executables.
@@ -22,26 +34,45 @@ var Core = fx.Options(
 	config.Module,
 	cache.Module,
 	database.Module,
+	health.Module,
 	logger.Module,
 	messaging.Module,
 	observability.Module,
 	repositoryorder.Module,
+	repositoryoutbox.Module,
+	repositorysequence.Module,
+	repositorysettings.Module,
 	serviceorder.Module,
+	servicesequence.Module,
+	servicesettings.Module,
 )
 
 // HTTP wires the HTTP transport on top of the core modules.
 var HTTP = fx.Options(
 	Core,
+	maintenance.Module,
 	httpserver.Module,
 	transporthttp.Module,
 )
 
+// GRPC wires the gRPC server and its services. It expects Core to be present.
+var GRPC = fx.Options(
+	servergrpc.Module,
+	transportgrpc.Module,
+)
+
 // Worker exposes background worker processing.
 var Worker = fx.Options(
 	Core,
+	scheduler.Module,
 	worker.Module,
 	workerorder.Module,
+	workeroutbox.Module,
+	workerwebhook.Module,
 )
 
-// Module is the default application wiring (HTTP only).
-var Module = HTTP
+// Module is the default application wiring: HTTP and gRPC side by side.
+var Module = fx.Options(
+	HTTP,
+	GRPC,
+)
@@ -3,14 +3,14 @@ package cache
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
-	goredis "github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/health"
+	"github.com/Additional-Code/atlas/internal/observability"
 )
 
 // Store represents a generic cache backend.
@@ -18,25 +18,85 @@ type Store interface {
 	Get(ctx context.Context, key string) ([]byte, error)
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// GetMulti returns the values found for keys; missing keys are simply absent
+	// from the result rather than failing the batch.
+	GetMulti(ctx context.Context, keys []string) (map[string][]byte, error)
+	// SetMulti writes every item with the same ttl (ttl<=0 uses the default).
+	SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error
+}
+
+// healthChecker is implemented by stores backed by a remote server.
+type healthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// checkHealth checks store when it is remote; in-process stores are always up.
+func checkHealth(ctx context.Context, store Store) error {
+	if checker, ok := store.(healthChecker); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
 }
 
 // ErrCacheMiss indicates the key is absent from the cache.
 var ErrCacheMiss = errors.New("cache miss")
 
-// Module provides the cache store to the Fx graph.
-var Module = fx.Provide(NewStore)
+// Module provides the cache store and its readiness check to the Fx graph.
+var Module = fx.Provide(NewStore, health.AsHealthCheck(newHealthCheck))
+
+// newHealthCheck checks stores backed by a remote server; others opt out.
+func newHealthCheck(store Store) health.HealthCheck {
+	checker, ok := store.(healthChecker)
+	if !ok {
+		return health.HealthCheck{}
+	}
+	return health.HealthCheck{Name: "cache", Check: checker.HealthCheck}
+}
+
+// NewStore initialises the configured cache store (redis, memory, tiered, or
+// noop), instrumenting it with metrics when they are enabled.
+func NewStore(lc fx.Lifecycle, cfg config.Config, obs *observability.Manager, logger *zap.Logger) (Store, error) {
+	store, err := newDriverStore(lc, cfg.Cache, cfg.Cache.Driver, logger)
+	if err != nil {
+		return nil, err
+	}
+	if obs == nil || !obs.MetricsEnabled() {
+		return store, nil
+	}
+	return Instrument(store, cfg.Cache.Driver)
+}
 
-// NewStore initialises the configured cache store (redis or noop).
-func NewStore(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Store, error) {
-	switch cfg.Cache.Driver {
+func newDriverStore(lc fx.Lifecycle, cfg config.Cache, driver string, logger *zap.Logger) (Store, error) {
+	switch driver {
 	case "noop":
 		logger.Info("cache disabled; using noop store")
 
 		return noopStore{}, nil
 	case "redis":
-		return newRedisStore(lc, cfg.Cache, logger)
+		return newRedisStore(lc, cfg, logger)
+	case "memory":
+		return newMemoryStore(lc, cfg, logger)
+	case "lru":
+		// Only valid as a tiered L1; its entries expire with the L1 TTL.
+		return newLRUStore(cfg.Tiered.L1Size, cfg.Tiered.L1TTL), nil
+	case "tiered":
+		l1, err := newDriverStore(lc, cfg, cfg.Tiered.L1Driver, logger)
+		if err != nil {
+			return nil, err
+		}
+		l2, err := newDriverStore(lc, cfg, cfg.Tiered.L2Driver, logger)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("tiered cache configured",
+			zap.String("l1", cfg.Tiered.L1Driver),
+			zap.String("l2", cfg.Tiered.L2Driver),
+			zap.Duration("l1_ttl", cfg.Tiered.L1TTL),
+		)
+
+		return NewTiered(l1, l2, cfg.Tiered.L1TTL), nil
 	default:
-		return nil, fmt.Errorf("unsupported cache driver: %s", cfg.Cache.Driver)
+		return nil, config.NewUnsupportedDriverError(config.ErrUnsupportedCacheDriver, driver)
 	}
 }
 
@@ -54,67 +114,10 @@ func (noopStore) Delete(context.Context, string) error {
 	return nil
 }
 
-type redisStore struct {
-	client     *goredis.Client
-	defaultTTL time.Duration
-}
-
-func newRedisStore(lc fx.Lifecycle, cfg config.Cache, logger *zap.Logger) (Store, error) {
-	opts := &goredis.Options{
-		Addr:     cfg.Redis.Addr,
-		Password: cfg.Redis.Password,
-		DB:       cfg.Redis.DB,
-	}
-
-	client := goredis.NewClient(opts)
-	store := &redisStore{client: client, defaultTTL: cfg.DefaultTTL}
-
-	lc.Append(fx.Hook{
-		OnStart: func(ctx context.Context) error {
-			if err := client.Ping(ctx).Err(); err != nil {
-				return fmt.Errorf("ping redis: %w", err)
-			}
-			logger.Info("redis cache connected", zap.String("addr", cfg.Redis.Addr))
-
-			return nil
-		},
-		OnStop: func(ctx context.Context) error {
-			logger.Info("closing redis cache")
-
-			return client.Close()
-		},
-	})
-
-	return store, nil
-}
-
-func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
-	if key == "" {
-		return nil, ErrCacheMiss
-	}
-	res, err := s.client.Get(ctx, key).Bytes()
-	if errors.Is(err, goredis.Nil) {
-		return nil, ErrCacheMiss
-	}
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
-}
-
-func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
-	if key == "" {
-		return errors.New("cache key is required")
-	}
-	if ttl <= 0 {
-		ttl = s.defaultTTL
-	}
-	return s.client.Set(ctx, key, value, ttl).Err()
+func (noopStore) GetMulti(context.Context, []string) (map[string][]byte, error) {
+	return map[string][]byte{}, nil
 }
 
-func (s *redisStore) Delete(ctx context.Context, key string) error {
-	if key == "" {
-		return nil
-	}
-	return s.client.Del(ctx, key).Err()
+func (noopStore) SetMulti(context.Context, map[string][]byte, time.Duration) error {
+	return nil
 }
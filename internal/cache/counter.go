@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"errors"
+)
+
+// Counter atomically increments integer keys shared between instances. Only
+// stores backed by a shared server implement it.
+type Counter interface {
+	// Incr adds one to key, starting from zero, and returns the new value.
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// AsCounter returns the shared Counter behind store, looking through the
+// metrics decorator and a tiered store's L2.
+func AsCounter(store Store) (Counter, bool) {
+	switch s := store.(type) {
+	case Counter:
+		return s, true
+	case *instrumentedStore:
+		return AsCounter(s.next)
+	case *Tiered:
+		return AsCounter(s.l2)
+	}
+	return nil, false
+}
+
+func (s *redisStore) Incr(ctx context.Context, key string) (int64, error) {
+	if key == "" {
+		return 0, errors.New("cache key is required")
+	}
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	return s.client.Incr(ctx, s.key(key)).Result()
+}
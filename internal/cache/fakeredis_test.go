@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// fakeRedis is a minimal RESP2 server for tests. It understands PING, GET,
+// SET (with EX/PX), DEL, MGET and INCR, and answers anything else with an
+// error, which go-redis tolerates during its connection handshake. With stall
+// set it accepts connections but never replies.
+type fakeRedis struct {
+	listener net.Listener
+	stall    bool
+
+	mu    sync.Mutex
+	items map[string]fakeRedisEntry
+}
+
+type fakeRedisEntry struct {
+	value     string
+	expiresAt time.Time
+}
+
+func newFakeRedis(t *testing.T, stall bool) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	server := &fakeRedis{listener: listener, stall: stall, items: make(map[string]fakeRedisEntry)}
+	t.Cleanup(func() { _ = listener.Close() })
+	go server.serve()
+	return server
+}
+
+func (s *fakeRedis) Addr() string { return s.listener.Addr().String() }
+
+// keys returns the stored keys, as redis would report them without a prefix.
+func (s *fakeRedis) keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *fakeRedis) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	if s.stall {
+		_, _ = io.Copy(io.Discard, conn)
+		return
+	}
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, s.exec(args)); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected %q", line)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+	args := make([]string, n)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func bulk(value string) string { return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value) }
+
+const nilBulk = "$-1\r\n"
+
+func (s *fakeRedis) exec(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		if value, ok := s.lookup(args[1]); ok {
+			return bulk(value)
+		}
+		return nilBulk
+	case "MGET":
+		reply := fmt.Sprintf("*%d\r\n", len(args)-1)
+		for _, key := range args[1:] {
+			if value, ok := s.lookup(key); ok {
+				reply += bulk(value)
+			} else {
+				reply += nilBulk
+			}
+		}
+		return reply
+	case "SET":
+		entry := fakeRedisEntry{value: args[2]}
+		for i := 3; i+1 < len(args); i += 2 {
+			n, _ := strconv.Atoi(args[i+1])
+			switch strings.ToUpper(args[i]) {
+			case "EX":
+				entry.expiresAt = time.Now().Add(time.Duration(n) * time.Second)
+			case "PX":
+				entry.expiresAt = time.Now().Add(time.Duration(n) * time.Millisecond)
+			}
+		}
+		s.items[args[1]] = entry
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.lookup(key); ok {
+				delete(s.items, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "INCR":
+		value, _ := s.lookup(args[1])
+		n, _ := strconv.ParseInt(value, 10, 64)
+		n++
+		s.items[args[1]] = fakeRedisEntry{value: strconv.FormatInt(n, 10)}
+		return fmt.Sprintf(":%d\r\n", n)
+	default:
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+}
+
+func (s *fakeRedis) lookup(key string) (string, bool) {
+	entry, ok := s.items[key]
+	if !ok {
+		return "", false
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(s.items, key)
+		return "", false
+	}
+	return entry.value, true
+}
+
+// newTestRedisStore builds a redis store against addr with cfg's cache
+// settings, started and stopped with t.
+func newTestRedisStore(t *testing.T, addr string, cfg config.Cache) Store {
+	t.Helper()
+	cfg.Redis.Mode = config.RedisModeStandalone
+	cfg.Redis.Addr = addr
+	lc := fxtest.NewLifecycle(t)
+	store, err := newRedisStore(lc, cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("newRedisStore: %v", err)
+	}
+	lc.RequireStart()
+	t.Cleanup(lc.RequireStop)
+	return store
+}
@@ -0,0 +1,133 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "github.com/Additional-Code/atlas/cache"
+
+// instrumentedStore decorates a Store with hit/miss/error counters and latency.
+type instrumentedStore struct {
+	next    Store
+	driver  attribute.KeyValue
+	hits    metric.Int64Counter
+	misses  metric.Int64Counter
+	errors  metric.Int64Counter
+	latency metric.Float64Histogram
+}
+
+// Instrument wraps store so every operation records OpenTelemetry metrics.
+func Instrument(store Store, driver string) (Store, error) {
+	meter := otel.Meter(meterName)
+
+	hits, err := meter.Int64Counter("cache.hits", metric.WithDescription("Cache lookups that found a value"))
+	if err != nil {
+		return nil, err
+	}
+	misses, err := meter.Int64Counter("cache.misses", metric.WithDescription("Cache lookups that found nothing"))
+	if err != nil {
+		return nil, err
+	}
+	errCounter, err := meter.Int64Counter("cache.errors", metric.WithDescription("Cache operations that failed"))
+	if err != nil {
+		return nil, err
+	}
+	latency, err := meter.Float64Histogram("cache.duration",
+		metric.WithDescription("Cache operation latency"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumentedStore{
+		next:    store,
+		driver:  attribute.String("cache.driver", driver),
+		hits:    hits,
+		misses:  misses,
+		errors:  errCounter,
+		latency: latency,
+	}, nil
+}
+
+// HealthCheck delegates to the wrapped store.
+func (s *instrumentedStore) HealthCheck(ctx context.Context) error {
+	return checkHealth(ctx, s.next)
+}
+
+func (s *instrumentedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	start := time.Now()
+	value, err := s.next.Get(ctx, key)
+	attrs := s.attrs("get")
+	s.observe(ctx, start, attrs)
+
+	switch {
+	case err == nil:
+		s.hits.Add(ctx, 1, attrs)
+	case errors.Is(err, ErrCacheMiss):
+		s.misses.Add(ctx, 1, attrs)
+	default:
+		s.errors.Add(ctx, 1, attrs)
+	}
+	return value, err
+}
+
+func (s *instrumentedStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	start := time.Now()
+	err := s.next.Set(ctx, key, value, ttl)
+	s.record(ctx, start, "set", err)
+	return err
+}
+
+func (s *instrumentedStore) Delete(ctx context.Context, key string) error {
+	start := time.Now()
+	err := s.next.Delete(ctx, key)
+	s.record(ctx, start, "delete", err)
+	return err
+}
+
+func (s *instrumentedStore) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	start := time.Now()
+	values, err := s.next.GetMulti(ctx, keys)
+	attrs := s.attrs("get_multi")
+	s.observe(ctx, start, attrs)
+
+	if err != nil {
+		s.errors.Add(ctx, 1, attrs)
+		return values, err
+	}
+	s.hits.Add(ctx, int64(len(values)), attrs)
+	if missed := len(keys) - len(values); missed > 0 {
+		s.misses.Add(ctx, int64(missed), attrs)
+	}
+	return values, nil
+}
+
+func (s *instrumentedStore) SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	start := time.Now()
+	err := s.next.SetMulti(ctx, items, ttl)
+	s.record(ctx, start, "set_multi", err)
+	return err
+}
+
+func (s *instrumentedStore) record(ctx context.Context, start time.Time, operation string, err error) {
+	attrs := s.attrs(operation)
+	s.observe(ctx, start, attrs)
+	if err != nil {
+		s.errors.Add(ctx, 1, attrs)
+	}
+}
+
+func (s *instrumentedStore) observe(ctx context.Context, start time.Time, attrs metric.MeasurementOption) {
+	s.latency.Record(ctx, float64(time.Since(start))/float64(time.Millisecond), attrs)
+}
+
+func (s *instrumentedStore) attrs(operation string) metric.MeasurementOption {
+	return metric.WithAttributes(s.driver, attribute.String("cache.operation", operation))
+}
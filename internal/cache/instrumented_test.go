@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// counterTotals returns the summed value of every int64 counter in reader.
+func counterTotals(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	totals := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, point := range sum.DataPoints {
+					totals[m.Name] += point.Value
+				}
+			}
+		}
+	}
+	return totals
+}
+
+func TestInstrumentCountsHitsAndMisses(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	store, err := Instrument(NewLRU(8, time.Minute), "memory")
+	if err != nil {
+		t.Fatalf("Instrument: %v", err)
+	}
+	ctx := context.Background()
+	if err := store.Set(ctx, "order:1", []byte("v"), 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	_, _ = store.Get(ctx, "order:1")
+	_, _ = store.Get(ctx, "order:1")
+	_, _ = store.Get(ctx, "order:2")
+
+	totals := counterTotals(t, reader)
+	if totals["cache.hits"] != 2 || totals["cache.misses"] != 1 {
+		t.Fatalf("hits = %d, misses = %d; want 2 and 1", totals["cache.hits"], totals["cache.misses"])
+	}
+	if totals["cache.errors"] != 0 {
+		t.Fatalf("errors = %d, want 0", totals["cache.errors"])
+	}
+}
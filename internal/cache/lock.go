@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Locker grants expiring, owner-scoped leases on keys so replicas can
+// coordinate. Only stores shared between instances implement it.
+type Locker interface {
+	// TryLock acquires key for owner, or extends the lease owner already
+	// holds, for ttl. It reports whether owner holds the lease afterwards.
+	TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Unlock releases key if owner still holds it.
+	Unlock(ctx context.Context, key, owner string) error
+}
+
+// AsLocker returns the shared Locker behind store, looking through the
+// metrics decorator and a tiered store's L2.
+func AsLocker(store Store) (Locker, bool) {
+	switch s := store.(type) {
+	case Locker:
+		return s, true
+	case *instrumentedStore:
+		return AsLocker(s.next)
+	case *Tiered:
+		return AsLocker(s.l2)
+	}
+	return nil, false
+}
+
+// tryLockScript takes a free lease or renews the caller's own.
+var tryLockScript = goredis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// unlockScript deletes the lease only while the caller still owns it.
+var unlockScript = goredis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (s *redisStore) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	if key == "" || owner == "" {
+		return false, errors.New("lock key and owner are required")
+	}
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	held, err := tryLockScript.Run(ctx, s.client, []string{s.key(key)}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return held == 1, nil
+}
+
+func (s *redisStore) Unlock(ctx context.Context, key, owner string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	return unlockScript.Run(ctx, s.client, []string{s.key(key)}, owner).Err()
+}
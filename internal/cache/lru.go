@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// lruStore is a size-bounded in-process Store that evicts the least recently
+// used entry once full. Entries also expire after their TTL.
+type lruStore struct {
+	mu         sync.Mutex
+	capacity   int
+	defaultTTL time.Duration
+	order      *list.List
+	items      map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRU returns a Store holding at most capacity entries in process memory.
+func NewLRU(capacity int, defaultTTL time.Duration) Store {
+	return newLRUStore(capacity, defaultTTL)
+}
+
+func newLRUStore(capacity int, defaultTTL time.Duration) *lruStore {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &lruStore{
+		capacity:   capacity,
+		defaultTTL: defaultTTL,
+		order:      list.New(),
+		items:      make(map[string]*list.Element, capacity),
+	}
+}
+
+func (s *lruStore) Get(_ context.Context, key string) ([]byte, error) {
+	if key == "" {
+		return nil, ErrCacheMiss
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.items[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+	entry := elem.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		s.removeElement(elem)
+		return nil, ErrCacheMiss
+	}
+	s.order.MoveToFront(elem)
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (s *lruStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("cache key is required")
+	}
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	entry := &lruEntry{key: key, value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return nil
+	}
+	s.items[key] = s.order.PushFront(entry)
+	for s.order.Len() > s.capacity {
+		s.removeElement(s.order.Back())
+	}
+	return nil
+}
+
+func (s *lruStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.items[key]; ok {
+		s.removeElement(elem)
+	}
+	return nil
+}
+
+func (s *lruStore) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if value, err := s.Get(ctx, key); err == nil {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+func (s *lruStore) SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	for key, value := range items {
+		if err := s.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *lruStore) removeElement(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.items, elem.Value.(*lruEntry).key)
+}
@@ -0,0 +1,187 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// memoryStore is an in-process Store with per-key expiration.
+type memoryStore struct {
+	mu         sync.RWMutex
+	items      map[string]memoryEntry
+	defaultTTL time.Duration
+	keyPrefix  string
+	stop       chan struct{}
+	done       chan struct{}
+}
+
+func newMemoryStore(lc fx.Lifecycle, cfg config.Cache, logger *zap.Logger) (Store, error) {
+	store := &memoryStore{
+		items:      make(map[string]memoryEntry),
+		defaultTTL: cfg.DefaultTTL,
+		keyPrefix:  cfg.KeyPrefix,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			store.startSweeper(cfg.Memory.SweepInterval)
+			logger.Info("memory cache ready", zap.Duration("sweep_interval", cfg.Memory.SweepInterval))
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("closing memory cache")
+			store.stopSweeper()
+
+			return nil
+		},
+	})
+
+	return store, nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	if key == "" {
+		return nil, ErrCacheMiss
+	}
+
+	key = s.key(key)
+	s.mu.RLock()
+	entry, ok := s.items[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	if entry.expired(time.Now()) {
+		s.mu.Lock()
+		// Re-check under the write lock; a concurrent Set may have refreshed the key.
+		if current, ok := s.items[key]; ok && current.expired(time.Now()) {
+			delete(s.items, key)
+		}
+		s.mu.Unlock()
+		return nil, ErrCacheMiss
+	}
+
+	return append([]byte(nil), entry.value...), nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("cache key is required")
+	}
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+
+	s.mu.Lock()
+	s.items[s.key(key)] = entry
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	s.mu.Lock()
+	delete(s.items, s.key(key))
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *memoryStore) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, err := s.Get(ctx, key)
+		if errors.Is(err, ErrCacheMiss) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func (s *memoryStore) SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	for key, value := range items {
+		if err := s.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// key applies the configured namespace, mirroring the redis store.
+func (s *memoryStore) key(key string) string {
+	return s.keyPrefix + key
+}
+
+// sweep removes every expired entry.
+func (s *memoryStore) sweep() {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, entry := range s.items {
+		if entry.expired(now) {
+			delete(s.items, key)
+		}
+	}
+}
+
+func (s *memoryStore) startSweeper(interval time.Duration) {
+	if interval <= 0 || s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.sweep()
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *memoryStore) stopSweeper() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.done
+	s.stop = nil
+}
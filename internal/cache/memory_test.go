@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func newTestMemoryStore(t *testing.T, cfg config.Cache) Store {
+	t.Helper()
+	lc := fxtest.NewLifecycle(t)
+	store, err := newMemoryStore(lc, cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("newMemoryStore: %v", err)
+	}
+	lc.RequireStart()
+	t.Cleanup(lc.RequireStop)
+	return store
+}
+
+func TestMemoryStoreSetGetDelete(t *testing.T) {
+	store := newTestMemoryStore(t, config.Cache{DefaultTTL: time.Minute})
+	ctx := context.Background()
+
+	if _, err := store.Get(ctx, "order:1"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get on empty store = %v, want ErrCacheMiss", err)
+	}
+	value := []byte("v1")
+	if err := store.Set(ctx, "order:1", value, 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value[0] = 'x'
+
+	got, err := store.Get(ctx, "order:1")
+	if err != nil || string(got) != "v1" {
+		t.Fatalf("Get = %q, %v; want v1 unaffected by the caller's buffer", got, err)
+	}
+	if err := store.Delete(ctx, "order:1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "order:1"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get after Delete = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestMemoryStoreExpiresEntries(t *testing.T) {
+	store := newTestMemoryStore(t, config.Cache{DefaultTTL: time.Minute})
+	ctx := context.Background()
+
+	if err := store.Set(ctx, "short", []byte("v"), 20*time.Millisecond); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+	if _, err := store.Get(ctx, "short"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get after TTL = %v, want ErrCacheMiss", err)
+	}
+}
+
+func TestMemoryStoreSweeperRemovesExpiredEntries(t *testing.T) {
+	cfg := config.Cache{DefaultTTL: time.Minute, Memory: config.Memory{SweepInterval: 10 * time.Millisecond}}
+	store := newTestMemoryStore(t, cfg).(*memoryStore)
+
+	if err := store.Set(context.Background(), "short", []byte("v"), 5*time.Millisecond); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		store.mu.RLock()
+		n := len(store.items)
+		store.mu.RUnlock()
+		if n == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("sweeper left %d expired entries", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMemoryStoreAppliesKeyPrefix(t *testing.T) {
+	store := newTestMemoryStore(t, config.Cache{DefaultTTL: time.Minute, KeyPrefix: "svc-a:"}).(*memoryStore)
+	ctx := context.Background()
+
+	if err := store.SetMulti(ctx, map[string][]byte{"order:1": []byte("v1")}, 0); err != nil {
+		t.Fatalf("SetMulti: %v", err)
+	}
+	store.mu.RLock()
+	_, prefixed := store.items["svc-a:order:1"]
+	_, bare := store.items["order:1"]
+	store.mu.RUnlock()
+	if !prefixed || bare {
+		t.Fatalf("stored keys prefixed=%v bare=%v, want only svc-a:order:1", prefixed, bare)
+	}
+
+	got, err := store.GetMulti(ctx, []string{"order:1"})
+	if err != nil || string(got["order:1"]) != "v1" {
+		t.Fatalf("GetMulti = %q, %v; want v1 under the unprefixed key", got, err)
+	}
+	if err := store.Delete(ctx, "order:1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "order:1"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get after Delete = %v, want ErrCacheMiss", err)
+	}
+}
+
+// assertPartialGetMulti seeds two of three keys in store and checks that
+// GetMulti returns exactly those, omitting the miss rather than failing.
+func assertPartialGetMulti(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+	if err := store.SetMulti(ctx, map[string][]byte{"a": []byte("1"), "c": []byte("3")}, time.Minute); err != nil {
+		t.Fatalf("SetMulti: %v", err)
+	}
+
+	got, err := store.GetMulti(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("GetMulti: %v", err)
+	}
+	if len(got) != 2 || string(got["a"]) != "1" || string(got["c"]) != "3" {
+		t.Fatalf("GetMulti = %q, want a=1 and c=3", got)
+	}
+	if _, ok := got["b"]; ok {
+		t.Fatal("GetMulti returned an entry for the missing key")
+	}
+}
+
+func TestMemoryStoreGetMultiPartialHits(t *testing.T) {
+	assertPartialGetMulti(t, newTestMemoryStore(t, config.Cache{DefaultTTL: time.Minute}))
+}
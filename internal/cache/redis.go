@@ -0,0 +1,361 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+type redisStore struct {
+	client     goredis.UniversalClient
+	defaultTTL time.Duration
+	opTimeout  time.Duration
+	keyPrefix  string
+	// maxValueBytes skips caching larger values; 0 disables the limit.
+	maxValueBytes int
+	oversized     metric.Int64Counter
+	logger        *zap.Logger
+}
+
+func newRedisStore(lc fx.Lifecycle, cfg config.Cache, logger *zap.Logger) (Store, error) {
+	client, err := newRedisClient(cfg.Redis)
+	if err != nil {
+		return nil, err
+	}
+	oversized, err := otel.Meter(meterName).Int64Counter("cache.oversized",
+		metric.WithDescription("Cache writes skipped because the value exceeded CACHE_MAX_VALUE_BYTES"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	store := &redisStore{
+		client:        client,
+		defaultTTL:    cfg.DefaultTTL,
+		opTimeout:     cfg.OpTimeout,
+		keyPrefix:     cfg.KeyPrefix,
+		maxValueBytes: cfg.MaxValueBytes,
+		oversized:     oversized,
+		logger:        logger,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if err := pingRedis(ctx, client); err != nil {
+				return fmt.Errorf("ping redis (%s): %w", cfg.Redis.Mode, err)
+			}
+			logger.Info("redis cache connected",
+				zap.String("mode", cfg.Redis.Mode),
+				zap.Strings("addrs", redisAddrs(cfg.Redis)),
+			)
+
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("closing redis cache")
+
+			return client.Close()
+		},
+	})
+
+	return store, nil
+}
+
+// newRedisClient builds a standalone, sentinel-backed failover, or cluster
+// client. All three satisfy UniversalClient, so the store is mode-agnostic.
+// Each honours context deadlines, so CACHE_OP_TIMEOUT bounds an operation
+// rather than the 3s default socket timeouts.
+func newRedisClient(cfg config.Redis) (goredis.UniversalClient, error) {
+	tlsConfig, err := redisTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Mode {
+	case "", config.RedisModeStandalone:
+		return goredis.NewClient(&goredis.Options{
+			Addr:                  cfg.Addr,
+			Password:              cfg.Password,
+			DB:                    cfg.DB,
+			TLSConfig:             tlsConfig,
+			ContextTimeoutEnabled: true,
+		}), nil
+	case config.RedisModeSentinel:
+		return goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:            cfg.MasterName,
+			SentinelAddrs:         cfg.SentinelAddrs,
+			SentinelPassword:      cfg.SentinelPassword,
+			Password:              cfg.Password,
+			DB:                    cfg.DB,
+			TLSConfig:             tlsConfig,
+			ContextTimeoutEnabled: true,
+		}), nil
+	case config.RedisModeCluster:
+		return goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:                 cfg.ClusterAddrs,
+			Password:              cfg.Password,
+			TLSConfig:             tlsConfig,
+			ContextTimeoutEnabled: true,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported redis mode: %s", cfg.Mode)
+	}
+}
+
+// redisTLSConfig returns nil when TLS is disabled. A bad CA or key pair path is
+// reported here so misconfiguration fails at construction, not on first use.
+func redisTLSConfig(cfg config.RedisTLS) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("redis CA file %s contains no valid certificates", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// pingRedis verifies connectivity for the configured mode. Standalone and
+// sentinel clients ping the (discovered) master; cluster clients ping every shard.
+func pingRedis(ctx context.Context, client goredis.UniversalClient) error {
+	if cluster, ok := client.(*goredis.ClusterClient); ok {
+		return cluster.ForEachShard(ctx, func(ctx context.Context, shard *goredis.Client) error {
+			if err := shard.Ping(ctx).Err(); err != nil {
+				return fmt.Errorf("shard %s: %w", shard.Options().Addr, err)
+			}
+			return nil
+		})
+	}
+	return client.Ping(ctx).Err()
+}
+
+// HealthCheck pings redis within the operation timeout.
+func (s *redisStore) HealthCheck(ctx context.Context) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	return pingRedis(ctx, s.client)
+}
+
+func redisAddrs(cfg config.Redis) []string {
+	switch cfg.Mode {
+	case config.RedisModeSentinel:
+		return cfg.SentinelAddrs
+	case config.RedisModeCluster:
+		return cfg.ClusterAddrs
+	default:
+		return []string{cfg.Addr}
+	}
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if key == "" {
+		return nil, ErrCacheMiss
+	}
+	ctx, span := startSpan(ctx, "cache.get", key)
+	defer span.End()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	res, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Bool("cache.hit", true))
+	return res, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("cache key is required")
+	}
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	ctx, span := startSpan(ctx, "cache.set", key)
+	defer span.End()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	if s.tooLarge(ctx, key, value) {
+		span.SetAttributes(attribute.Bool("cache.skipped_oversized", true))
+		// Drop any earlier value so it is not served in place of this one.
+		if err := s.client.Del(ctx, s.key(key)).Err(); err != nil {
+			recordSpanError(span, err)
+			return err
+		}
+		return nil
+	}
+	if err := s.client.Set(ctx, s.key(key), value, ttl).Err(); err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (s *redisStore) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return nil
+	}
+	ctx, span := startSpan(ctx, "cache.delete", key)
+	defer span.End()
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	if err := s.client.Del(ctx, s.key(key)).Err(); err != nil {
+		recordSpanError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (s *redisStore) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(keys))
+	lookup := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			lookup = append(lookup, key)
+		}
+	}
+	if len(lookup) == 0 {
+		return result, nil
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	prefixed := make([]string, len(lookup))
+	for i, key := range lookup {
+		prefixed[i] = s.key(key)
+	}
+	values, err := s.mget(ctx, prefixed)
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		if str, ok := value.(string); ok {
+			result[lookup[i]] = []byte(str)
+		}
+	}
+	return result, nil
+}
+
+// mget fetches keys in one round trip. Cluster clients cannot MGET across hash
+// slots, so they fall back to pipelined GETs which go-redis routes per node.
+func (s *redisStore) mget(ctx context.Context, keys []string) ([]any, error) {
+	if _, ok := s.client.(*goredis.ClusterClient); !ok {
+		return s.client.MGet(ctx, keys...).Result()
+	}
+
+	cmds := make([]*goredis.StringCmd, len(keys))
+	_, err := s.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		return nil, err
+	}
+
+	values := make([]any, len(keys))
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			values[i] = value
+		}
+	}
+	return values, nil
+}
+
+func (s *redisStore) SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	_, err := s.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for key, value := range items {
+			if key == "" {
+				return errors.New("cache key is required")
+			}
+			if s.tooLarge(ctx, key, value) {
+				pipe.Del(ctx, s.key(key))
+				continue
+			}
+			pipe.Set(ctx, s.key(key), value, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// tooLarge reports whether value exceeds the size limit, recording the skip.
+// Oversized writes are dropped rather than failed, and callers delete the key
+// so the next read degrades to a miss.
+func (s *redisStore) tooLarge(ctx context.Context, key string, value []byte) bool {
+	if s.maxValueBytes <= 0 || len(value) <= s.maxValueBytes {
+		return false
+	}
+	s.oversized.Add(ctx, 1)
+	s.logger.Warn("cache value exceeds size limit; not cached",
+		zap.String("key", key),
+		zap.Int("bytes", len(value)),
+		zap.Int("max_bytes", s.maxValueBytes),
+	)
+	return true
+}
+
+// key applies the configured namespace so services sharing a redis don't collide.
+func (s *redisStore) key(key string) string {
+	return s.keyPrefix + key
+}
+
+// withTimeout bounds a single cache operation so a hung redis degrades to a
+// fast failure. Shorter deadlines already present on ctx still win.
+func (s *redisStore) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.opTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.opTimeout)
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func TestRedisStoreTimesOutOnStalledServer(t *testing.T) {
+	srv := newFakeRedis(t, true)
+	cfg := config.Cache{DefaultTTL: time.Minute, OpTimeout: 50 * time.Millisecond}
+	cfg.Redis = config.Redis{Mode: config.RedisModeStandalone, Addr: srv.Addr()}
+	// Not started: the startup ping would stall too.
+	store, err := newRedisStore(fxtest.NewLifecycle(t), cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("newRedisStore: %v", err)
+	}
+
+	start := time.Now()
+	_, err = store.Get(context.Background(), "order:1")
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("Get against a stalled server succeeded")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("Get took %s, want it bounded by CACHE_OP_TIMEOUT (%s)", elapsed, cfg.OpTimeout)
+	}
+}
+
+func TestRedisStorePrefixIsolatesStores(t *testing.T) {
+	srv := newFakeRedis(t, false)
+	a := newTestRedisStore(t, srv.Addr(), config.Cache{DefaultTTL: time.Minute, KeyPrefix: "svc-a:"})
+	b := newTestRedisStore(t, srv.Addr(), config.Cache{DefaultTTL: time.Minute, KeyPrefix: "svc-b:"})
+	ctx := context.Background()
+
+	if err := a.Set(ctx, "order:1", []byte("from-a"), 0); err != nil {
+		t.Fatalf("a.Set: %v", err)
+	}
+	if err := b.Set(ctx, "order:1", []byte("from-b"), 0); err != nil {
+		t.Fatalf("b.Set: %v", err)
+	}
+	if got, err := a.Get(ctx, "order:1"); err != nil || string(got) != "from-a" {
+		t.Fatalf("a.Get = %q, %v; want from-a", got, err)
+	}
+	if err := b.Delete(ctx, "order:1"); err != nil {
+		t.Fatalf("b.Delete: %v", err)
+	}
+	if got, err := a.Get(ctx, "order:1"); err != nil || string(got) != "from-a" {
+		t.Fatalf("a.Get after b.Delete = %q, %v; want from-a", got, err)
+	}
+
+	keys := srv.keys()
+	slices.Sort(keys)
+	if want := []string{"svc-a:order:1"}; !slices.Equal(keys, want) {
+		t.Fatalf("redis keys = %v, want %v", keys, want)
+	}
+}
+
+func TestNewRedisClientAppliesTLS(t *testing.T) {
+	tlsCfg := config.RedisTLS{Enabled: true, InsecureSkipVerify: true}
+
+	standalone, err := newRedisClient(config.Redis{Mode: config.RedisModeStandalone, Addr: "localhost:6379", TLS: tlsCfg})
+	if err != nil {
+		t.Fatalf("standalone client: %v", err)
+	}
+	defer standalone.Close()
+	if got := standalone.(*goredis.Client).Options().TLSConfig; got == nil || !got.InsecureSkipVerify {
+		t.Fatalf("standalone TLSConfig = %+v, want skip-verify set", got)
+	}
+
+	cluster, err := newRedisClient(config.Redis{Mode: config.RedisModeCluster, ClusterAddrs: []string{"localhost:7000"}, TLS: tlsCfg})
+	if err != nil {
+		t.Fatalf("cluster client: %v", err)
+	}
+	defer cluster.Close()
+	if got := cluster.(*goredis.ClusterClient).Options().TLSConfig; got == nil || !got.InsecureSkipVerify {
+		t.Fatalf("cluster TLSConfig = %+v, want skip-verify set", got)
+	}
+
+	plain, err := newRedisClient(config.Redis{Mode: config.RedisModeStandalone, Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("plain client: %v", err)
+	}
+	defer plain.Close()
+	if got := plain.(*goredis.Client).Options().TLSConfig; got != nil {
+		t.Fatalf("TLSConfig = %+v with TLS disabled, want nil", got)
+	}
+}
+
+func TestRedisTLSConfigRejectsMissingCA(t *testing.T) {
+	_, err := redisTLSConfig(config.RedisTLS{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	if err == nil {
+		t.Fatal("missing CA file accepted")
+	}
+}
+
+func TestRedisStoreGetMultiPartialHits(t *testing.T) {
+	srv := newFakeRedis(t, false)
+	assertPartialGetMulti(t, newTestRedisStore(t, srv.Addr(), config.Cache{DefaultTTL: time.Minute, KeyPrefix: "svc:"}))
+}
+
+func TestRedisStoreOversizedWriteDropsStaleValue(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	srv := newFakeRedis(t, false)
+	store := newTestRedisStore(t, srv.Addr(), config.Cache{DefaultTTL: time.Minute, MaxValueBytes: 8})
+	ctx := context.Background()
+	big := []byte("more than eight bytes")
+
+	for _, key := range []string{"order:1", "order:2"} {
+		if err := store.Set(ctx, key, []byte("small"), 0); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	if err := store.Set(ctx, "order:1", big, 0); err != nil {
+		t.Fatalf("oversized Set = %v, want it skipped without error", err)
+	}
+	if err := store.SetMulti(ctx, map[string][]byte{"order:2": big, "order:3": []byte("ok")}, 0); err != nil {
+		t.Fatalf("SetMulti: %v", err)
+	}
+
+	for _, key := range []string{"order:1", "order:2"} {
+		if got, err := store.Get(ctx, key); !errors.Is(err, ErrCacheMiss) {
+			t.Errorf("Get %s after an oversized write = %q, %v; want a miss, not the stale value", key, got, err)
+		}
+	}
+	if got, err := store.Get(ctx, "order:3"); err != nil || string(got) != "ok" {
+		t.Errorf("Get order:3 = %q, %v; want the small value written", got, err)
+	}
+	if got := counterTotals(t, reader)["cache.oversized"]; got != 2 {
+		t.Errorf("cache.oversized = %d, want 2", got)
+	}
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Loader produces the value to cache when a key is missing.
+type Loader func() ([]byte, error)
+
+// Remember returns the cached value for key, calling loader and caching its
+// result on ErrCacheMiss. Read errors other than a miss and loader errors are
+// returned as-is, and nothing is written when the loader fails. If only the
+// cache write fails, the loaded value is returned together with the write error
+// so callers can degrade gracefully.
+func Remember(ctx context.Context, store Store, key string, ttl time.Duration, loader Loader) ([]byte, error) {
+	if store == nil {
+		return loader()
+	}
+
+	value, err := store.Get(ctx, key)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, ErrCacheMiss) {
+		return nil, err
+	}
+
+	value, err = loader()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Set(ctx, key, value, ttl); err != nil {
+		return value, fmt.Errorf("cache set %s: %w", key, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// countingLoader returns value, or err when set, counting its calls.
+type countingLoader struct {
+	calls int
+	value []byte
+	err   error
+}
+
+func (l *countingLoader) load() ([]byte, error) {
+	l.calls++
+	return l.value, l.err
+}
+
+func TestRememberHitSkipsLoader(t *testing.T) {
+	store := NewLRU(16, time.Minute)
+	ctx := context.Background()
+	if err := store.Set(ctx, "k", []byte("cached"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	loader := &countingLoader{value: []byte("loaded")}
+	value, err := Remember(ctx, store, "k", time.Minute, loader.load)
+	if err != nil || string(value) != "cached" {
+		t.Fatalf("Remember = %q, %v; want the cached value", value, err)
+	}
+	if loader.calls != 0 {
+		t.Fatalf("loader calls = %d on a hit, want 0", loader.calls)
+	}
+}
+
+func TestRememberMissLoadsAndCaches(t *testing.T) {
+	store := NewLRU(16, time.Minute)
+	ctx := context.Background()
+
+	loader := &countingLoader{value: []byte("loaded")}
+	for range 2 {
+		value, err := Remember(ctx, store, "k", time.Minute, loader.load)
+		if err != nil || string(value) != "loaded" {
+			t.Fatalf("Remember = %q, %v; want the loaded value", value, err)
+		}
+	}
+	if loader.calls != 1 {
+		t.Fatalf("loader calls = %d, want 1 with the second read cached", loader.calls)
+	}
+}
+
+func TestRememberLoaderErrorCachesNothing(t *testing.T) {
+	store := NewLRU(16, time.Minute)
+	ctx := context.Background()
+
+	failed := errors.New("database down")
+	value, err := Remember(ctx, store, "k", time.Minute, (&countingLoader{value: []byte("partial"), err: failed}).load)
+	if !errors.Is(err, failed) || value != nil {
+		t.Fatalf("Remember = %q, %v; want no value and the loader error", value, err)
+	}
+	if _, err := store.Get(ctx, "k"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("Get after a failed load = %v, want ErrCacheMiss", err)
+	}
+}
+
+// failingSetStore misses every read and fails every write.
+type failingSetStore struct{ Store }
+
+func (failingSetStore) Get(context.Context, string) ([]byte, error) { return nil, ErrCacheMiss }
+
+func (failingSetStore) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("read-only replica")
+}
+
+func TestRememberFailedWriteReturnsValueAndError(t *testing.T) {
+	value, err := Remember(context.Background(), failingSetStore{}, "k", time.Minute, (&countingLoader{value: []byte("loaded")}).load)
+	if err == nil {
+		t.Fatal("Remember hid the cache write error")
+	}
+	if string(value) != "loaded" {
+		t.Fatalf("value = %q, want the loaded value alongside the write error", value)
+	}
+}
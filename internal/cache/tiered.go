@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tiered composes a fast L1 store in front of a shared L2 store. Reads fall
+// through to L2 and populate L1 on hit; writes and deletes go to both layers.
+type Tiered struct {
+	l1    Store
+	l2    Store
+	l1TTL time.Duration
+}
+
+// NewTiered builds a Tiered store. l1TTL caps how long L1 keeps a value so that
+// instances converge on L2 updates; zero means use the caller's TTL as-is.
+func NewTiered(l1, l2 Store, l1TTL time.Duration) *Tiered {
+	return &Tiered{l1: l1, l2: l2, l1TTL: l1TTL}
+}
+
+// HealthCheck checks both layers.
+func (t *Tiered) HealthCheck(ctx context.Context) error {
+	return errors.Join(checkHealth(ctx, t.l1), checkHealth(ctx, t.l2))
+}
+
+// Get reads from L1, falling back to L2 and promoting hits into L1.
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, err := t.l1.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := t.l2.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	_ = t.l1.Set(ctx, key, value, t.l1TTLFor(0))
+	return value, nil
+}
+
+// Set writes through to L2 and then L1. An L2 failure is returned and L1 is
+// left untouched so the layers do not diverge.
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := t.l2.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	return t.l1.Set(ctx, key, value, t.l1TTLFor(ttl))
+}
+
+// Delete removes the key from both layers.
+func (t *Tiered) Delete(ctx context.Context, key string) error {
+	return errors.Join(t.l1.Delete(ctx, key), t.l2.Delete(ctx, key))
+}
+
+// GetMulti serves what it can from L1 and loads the remainder from L2.
+func (t *Tiered) GetMulti(ctx context.Context, keys []string) (map[string][]byte, error) {
+	result, err := t.l1.GetMulti(ctx, keys)
+	if err != nil {
+		result = make(map[string][]byte, len(keys))
+	}
+
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := result[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	found, err := t.l2.GetMulti(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) > 0 {
+		_ = t.l1.SetMulti(ctx, found, t.l1TTLFor(0))
+	}
+	for key, value := range found {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// SetMulti writes every item through to L2 and then L1.
+func (t *Tiered) SetMulti(ctx context.Context, items map[string][]byte, ttl time.Duration) error {
+	if err := t.l2.SetMulti(ctx, items, ttl); err != nil {
+		return err
+	}
+	return t.l1.SetMulti(ctx, items, t.l1TTLFor(ttl))
+}
+
+func (t *Tiered) l1TTLFor(ttl time.Duration) time.Duration {
+	if t.l1TTL > 0 && (ttl <= 0 || ttl > t.l1TTL) {
+		return t.l1TTL
+	}
+	return ttl
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func TestTieredPromotesL2HitsIntoL1(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := NewLRU(4, time.Minute), NewLRU(16, time.Minute)
+	tiered := NewTiered(l1, l2, time.Minute)
+
+	if err := l2.Set(ctx, "order:1", []byte("v1"), time.Minute); err != nil {
+		t.Fatalf("seed L2: %v", err)
+	}
+	value, err := tiered.Get(ctx, "order:1")
+	if err != nil || string(value) != "v1" {
+		t.Fatalf("Get = %q, %v; want v1", value, err)
+	}
+	if value, err := l1.Get(ctx, "order:1"); err != nil || string(value) != "v1" {
+		t.Fatalf("L1 after promotion = %q, %v; want v1", value, err)
+	}
+}
+
+func TestTieredDeleteRemovesFromBothLayers(t *testing.T) {
+	ctx := context.Background()
+	l1, l2 := NewLRU(4, time.Minute), NewLRU(16, time.Minute)
+	tiered := NewTiered(l1, l2, time.Minute)
+
+	if err := tiered.Set(ctx, "order:1", []byte("v1"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := tiered.Delete(ctx, "order:1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	for name, layer := range map[string]Store{"L1": l1, "L2": l2} {
+		if _, err := layer.Get(ctx, "order:1"); !errors.Is(err, ErrCacheMiss) {
+			t.Fatalf("%s Get after Delete = %v, want a miss", name, err)
+		}
+	}
+}
+
+func TestTieredLRUL1IsBounded(t *testing.T) {
+	var cfg config.Cache
+	cfg.Tiered = config.Tiered{L1Driver: "lru", L1TTL: time.Minute, L1Size: 2}
+	store, err := newDriverStore(nil, cfg, "lru", zap.NewNop())
+	if err != nil {
+		t.Fatalf("lru layer: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		if err := store.Set(ctx, key, []byte(key), 0); err != nil {
+			t.Fatalf("Set %s: %v", key, err)
+		}
+	}
+	if _, err := store.Get(ctx, "a"); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("oldest entry still cached beyond CACHE_TIERED_L1_SIZE: %v", err)
+	}
+	if _, err := store.Get(ctx, "c"); err != nil {
+		t.Fatalf("newest entry missing: %v", err)
+	}
+}
+
+func TestTieredDriverPromotesL2MultiHits(t *testing.T) {
+	cfg := config.Cache{DefaultTTL: time.Minute}
+	cfg.Tiered = config.Tiered{L1Driver: "memory", L2Driver: "memory", L1TTL: time.Second}
+	lc := fxtest.NewLifecycle(t)
+	store, err := newDriverStore(lc, cfg, "tiered", zap.NewNop())
+	if err != nil {
+		t.Fatalf("tiered driver: %v", err)
+	}
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	tiered := store.(*Tiered)
+	ctx := context.Background()
+	if err := tiered.l2.SetMulti(ctx, map[string][]byte{"a": []byte("1"), "b": []byte("2")}, 0); err != nil {
+		t.Fatalf("seed L2: %v", err)
+	}
+
+	got, err := tiered.GetMulti(ctx, []string{"a", "b", "c"})
+	if err != nil || len(got) != 2 {
+		t.Fatalf("GetMulti = %v, %v; want a and b", got, err)
+	}
+	inL1, err := tiered.l1.GetMulti(ctx, []string{"a", "b"})
+	if err != nil || len(inL1) != 2 {
+		t.Fatalf("L1 after GetMulti = %v, %v; want a and b promoted", inL1, err)
+	}
+}
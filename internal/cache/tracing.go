@@ -0,0 +1,38 @@
+package cache
+
+import (
+	"context"
+	"encoding/hex"
+	"hash/fnv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// cacheTracer resolves to a no-op tracer when tracing is disabled.
+var cacheTracer = otel.Tracer("github.com/Additional-Code/atlas/cache")
+
+// startSpan opens a client span for a cache operation. Keys may embed
+// identifiers, so only a hash of the key is recorded.
+func startSpan(ctx context.Context, name, key string) (context.Context, trace.Span) {
+	return cacheTracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("cache.key_hash", hashKey(key)),
+		),
+	)
+}
+
+func recordSpanError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "cache operation failed")
+}
+
+func hashKey(key string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return hex.EncodeToString(h.Sum(nil))
+}
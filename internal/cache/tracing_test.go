@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func TestRedisGetStartsChildSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	// cacheTracer was resolved at init; the global delegate forwards it here.
+	otel.SetTracerProvider(provider)
+
+	srv := newFakeRedis(t, false)
+	store := newTestRedisStore(t, srv.Addr(), config.Cache{DefaultTTL: time.Minute})
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+	_, _ = store.Get(ctx, "order:1")
+	parent.End()
+
+	var get sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "cache.get" {
+			get = span
+		}
+	}
+	if get == nil {
+		t.Fatal("no cache.get span recorded")
+	}
+	if get.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("cache.get is not a child of the caller's span")
+	}
+	if get.SpanKind() != trace.SpanKindClient {
+		t.Fatalf("span kind = %s, want client", get.SpanKind())
+	}
+	var hit attribute.Value
+	for _, attr := range get.Attributes() {
+		if attr.Key == "cache.hit" {
+			hit = attr.Value
+		}
+	}
+	if hit.Type() != attribute.BOOL || hit.AsBool() {
+		t.Fatalf("cache.hit = %v, want false for a miss", hit.Emit())
+	}
+}
@@ -2,15 +2,25 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/app"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/maintenance"
 	"github.com/Additional-Code/atlas/internal/migration"
+	"github.com/Additional-Code/atlas/internal/scaffold"
 	"github.com/Additional-Code/atlas/internal/seeder"
 )
 
@@ -26,13 +36,17 @@ func NewRootCommand() *cobra.Command {
 	root.AddCommand(newSeedCmd())
 	root.AddCommand(newModuleCmd())
 	root.AddCommand(newWorkerCmd())
+	root.AddCommand(newQueryCmd())
 
 	return root
 }
 
-// Execute runs the atlas CLI.
+// Execute runs the atlas CLI, cancelling the command context on SIGINT/SIGTERM.
 func Execute() error {
-	if err := NewRootCommand().Execute(); err != nil {
+	ctx, stop := withShutdownSignals(context.Background(), os.Stderr)
+	defer stop()
+
+	if err := NewRootCommand().ExecuteContext(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		return err
 	}
@@ -45,12 +59,13 @@ func newStartCmd() *cobra.Command {
 		Aliases: []string{"run"},
 		Short:   "Run the HTTP service",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			application := fx.New(app.Module)
+			application := fx.New(app.Module, logShutdownSignals(cmd.Context()))
 			if err := application.Start(cmd.Context()); err != nil {
 				return err
 			}
 			<-cmd.Context().Done()
-			stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			// Leaves room for HTTP_SHUTDOWN_TIMEOUT plus the remaining stop hooks.
+			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 			return application.Stop(stopCtx)
 		},
@@ -99,10 +114,76 @@ func newMigrateCmd() *cobra.Command {
 	downCmd.Flags().Int("steps", 1, "Number of migration steps to rollback")
 	downCmd.Flags().Bool("all", false, "Rollback all applied migrations")
 
-	cmd.AddCommand(upCmd, downCmd)
+	toCmd := &cobra.Command{
+		Use:   "to <version>",
+		Short: "Migrate up or down to an exact version",
+		Long: "Applies or rolls back migrations until the schema is at version. " +
+			"Use 0 to roll everything back; any other version must match a migration file.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid version %q: %w", args[0], err)
+			}
+			var mig *migration.Migrator
+			opts := fx.Options(app.Core, migration.Module, fx.Populate(&mig))
+			return runWithApp(cmd.Context(), opts, func(ctx context.Context) error {
+				from, err := mig.MigrateTo(ctx, version)
+				if err != nil {
+					return err
+				}
+				if from == version {
+					fmt.Fprintf(cmd.OutOrStdout(), "schema already at version %d; nothing to do\n", version)
+					return nil
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "schema migrated from version %d to %d\n", from, version)
+				return nil
+			})
+		},
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var mig *migration.Migrator
+			opts := fx.Options(app.Core, migration.Module, fx.Populate(&mig))
+			return runWithApp(cmd.Context(), opts, func(ctx context.Context) error {
+				statuses, err := mig.Status(ctx)
+				if err != nil {
+					return err
+				}
+				return printMigrationStatus(cmd.OutOrStdout(), statuses)
+			})
+		},
+	}
+
+	cmd.AddCommand(upCmd, downCmd, toCmd, statusCmd)
 	return cmd
 }
 
+// printMigrationStatus renders one row per migration in version order and a
+// summary line.
+func printMigrationStatus(out io.Writer, statuses []migration.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	pending := 0
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.Applied {
+			appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+		} else {
+			pending++
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%d applied, %d pending\n", len(statuses)-pending, pending)
+	return err
+}
+
 func newSeedCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "seed",
@@ -129,10 +210,21 @@ func newModuleCmd() *cobra.Command {
 	cmd.AddCommand(&cobra.Command{
 		Use:   "create [name]",
 		Short: "Create a new domain module",
-		Args:  cobra.ExactArgs(1),
+		Long: "Generates an entity, repository, service, DTO, HTTP handler, and worker " +
+			"handler for name under internal/, mirroring the order module. Run it from the " +
+			"repository root; it refuses to overwrite existing files or packages.",
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			name := args[0]
-			fmt.Fprintf(cmd.OutOrStdout(), "module %s scaffolded (placeholder)\n", name)
+			created, err := scaffold.Generate(".", name)
+			if err != nil {
+				return err
+			}
+			out := cmd.OutOrStdout()
+			for _, path := range created {
+				fmt.Fprintf(out, "created %s\n", path)
+			}
+			fmt.Fprintf(out, "module %s scaffolded; add a migration for its table and wire its Fx modules in internal/app and internal/transport/http\n", name)
 			return nil
 		},
 	})
@@ -144,25 +236,97 @@ func newWorkerCmd() *cobra.Command {
 		Use:   "worker",
 		Short: "Manage background workers",
 	}
-	cmd.AddCommand(&cobra.Command{
+	cmd.AddCommand(newWorkerTailCmd())
+	cmd.AddCommand(newWorkerReplayCmd())
+	cmd.AddCommand(newWorkerRunCmd())
+	return cmd
+}
+
+func newWorkerRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "Run worker engine",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			application := fx.New(app.Worker)
+			exitWhenIdle, _ := cmd.Flags().GetBool("exit-when-idle")
+
+			var (
+				cfg    config.Config
+				logger *zap.Logger
+			)
+			application := fx.New(app.Worker, fx.Populate(&cfg, &logger), logShutdownSignals(cmd.Context()))
 			if err := application.Start(cmd.Context()); err != nil {
 				return err
 			}
+			stop := func() error {
+				stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				return application.Stop(stopCtx)
+			}
+
+			// With messaging off there is nothing to consume or relay; say so
+			// loudly rather than idle looking healthy.
+			if !cfg.Messaging.Enabled {
+				logger.Warn("messaging is disabled (MESSAGING_ENABLED=false); this worker will not process anything")
+				if exitWhenIdle {
+					return &exitError{
+						code: ExitCodeWorkerIdle,
+						err:  errors.Join(errors.New("worker has nothing to consume: messaging is disabled"), stop()),
+					}
+				}
+			}
+
 			<-cmd.Context().Done()
-			stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-			return application.Stop(stopCtx)
+			return stop()
+		},
+	}
+	cmd.Flags().Bool("exit-when-idle", false, fmt.Sprintf("Exit with status %d instead of idling when messaging is disabled", ExitCodeWorkerIdle))
+	return cmd
+}
+
+func newQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query",
+		Short: "Run whitelisted maintenance queries",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List available queries",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, q := range maintenance.Queries() {
+				fmt.Fprintf(cmd.OutOrStdout(), "%-20s %s (params: %s)\n", q.Name, q.Description, strings.Join(q.Params, ", "))
+			}
+			return nil
 		},
 	})
+
+	runCmd := &cobra.Command{
+		Use:   "run [name]",
+		Short: "Run a named query",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rawParams, _ := cmd.Flags().GetStringToString("param")
+			var runner *maintenance.Runner
+			opts := fx.Options(app.Core, maintenance.Module, fx.Populate(&runner))
+			return runWithApp(cmd.Context(), opts, func(ctx context.Context) error {
+				rows, err := runner.Run(ctx, args[0], rawParams)
+				if err != nil {
+					return err
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(rows)
+			})
+		},
+	}
+	runCmd.Flags().StringToString("param", nil, "Query parameters as key=value pairs")
+
+	cmd.AddCommand(runCmd)
 	return cmd
 }
 
 func runWithApp(ctx context.Context, opts fx.Option, fn func(context.Context) error) error {
-	application := fx.New(opts, fx.NopLogger)
+	application := fx.New(opts, fx.NopLogger, logShutdownSignals(ctx))
 	if err := application.Start(ctx); err != nil {
 		return err
 	}
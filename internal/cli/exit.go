@@ -0,0 +1,30 @@
+package cli
+
+import "errors"
+
+// ExitCodeWorkerIdle is the exit status of `worker run --exit-when-idle` when
+// messaging is disabled and the worker has nothing to consume.
+const ExitCodeWorkerIdle = 3
+
+// exitError carries a specific process exit status for a command failure.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode maps a command error onto the process exit status: the command's
+// own status when it set one, otherwise 1.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return 1
+}
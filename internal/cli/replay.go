@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	"github.com/Additional-Code/atlas/internal/observability"
+)
+
+// replayGroupSuffix names the consumer group that remembers how far the DLQ
+// has been replayed, separate from the workers' group.
+const replayGroupSuffix = "-dlq-replay"
+
+func newWorkerReplayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-dlq",
+		Short: "Re-publish dead-lettered messages to their original topics",
+		Long: "Consumes the DLQ topic and re-publishes each message to the topic named in its " +
+			"dlq-original-topic header, with the dlq-* metadata removed and a dlq-replays count " +
+			"added. Messages replayed --max-replays times are skipped. Progress is committed under " +
+			"its own consumer group, so a later run resumes where this one stopped, and a failed " +
+			"re-publish ends the run with that message uncommitted. Payloads are replayed exactly " +
+			"as stored. --dry-run reads with a throwaway group from the beginning and publishes nothing.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic, _ := cmd.Flags().GetString("topic")
+			maxReplays, _ := cmd.Flags().GetInt("max-replays")
+			perSecond, _ := cmd.Flags().GetFloat64("rate")
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			idleTimeout, _ := cmd.Flags().GetDuration("idle-timeout")
+			if maxReplays < 0 || perSecond < 0 || idleTimeout < 0 {
+				return errors.New("--max-replays, --rate and --idle-timeout must not be negative")
+			}
+
+			var (
+				client messaging.Client
+				log    *zap.Logger
+			)
+			opts := fx.Options(
+				config.Module,
+				logger.Module,
+				observability.Module,
+				messaging.Module,
+				fx.Decorate(func(cfg config.Config) config.Config {
+					if topic == "" {
+						topic = cfg.Messaging.Kafka.DLQ.Topic
+					}
+					cfg.Messaging.Kafka.Topic = topic
+					cfg.Messaging.Kafka.Topics = []string{topic}
+					cfg.Messaging.Kafka.StartOffset = config.KafkaOffsetFirst
+					if dryRun {
+						cfg.Messaging.ConsumerGroup = fmt.Sprintf("%s-dry-run-%d-%d", cfg.Messaging.ConsumerGroup+replayGroupSuffix, os.Getpid(), time.Now().Unix())
+					} else {
+						cfg.Messaging.ConsumerGroup += replayGroupSuffix
+					}
+					return cfg
+				}),
+				fx.Populate(&client, &log),
+			)
+			return runWithApp(cmd.Context(), opts, func(ctx context.Context) error {
+				raw, ok := client.(messaging.RawClient)
+				if !ok {
+					return errors.New("replay-dlq needs a broker-backed messaging driver")
+				}
+				replayer := messaging.NewReplayer(raw, messaging.ReplayOptions{
+					MaxReplays: maxReplays,
+					Rate:       perSecond,
+					DryRun:     dryRun,
+				}, log)
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "replaying %s (ctrl+c to stop)\n", client.Topic())
+				err := consumeUntilIdle(ctx, raw.ConsumeRaw, replayer.Handle, idleTimeout)
+
+				stats := replayer.Stats()
+				verb := "replayed"
+				if dryRun {
+					verb = "would replay"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %d message(s), skipped %d\n", verb, stats.Replayed, stats.Skipped)
+				return err
+			})
+		},
+	}
+	cmd.Flags().String("topic", "", "DLQ topic to replay (defaults to KAFKA_DLQ_TOPIC)")
+	cmd.Flags().Int("max-replays", 3, "Skip messages already replayed this many times (0 = no cap)")
+	cmd.Flags().Float64("rate", 10, "Maximum messages re-published per second (0 = unlimited)")
+	cmd.Flags().Bool("dry-run", false, "Log what would be replayed without publishing or committing")
+	cmd.Flags().Duration("idle-timeout", 0, "Stop after no message arrives for this long (0 = run until interrupted)")
+	return cmd
+}
+
+// consumeUntilIdle runs consume with handler until ctx is cancelled or, with a
+// positive idle timeout, no message has arrived for that long.
+func consumeUntilIdle(ctx context.Context, consume func(context.Context, messaging.Handler) error, handler messaging.Handler, idle time.Duration) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if idle > 0 {
+		timer := time.AfterFunc(idle, cancel)
+		defer timer.Stop()
+		next := handler
+		handler = func(ctx context.Context, msg messaging.Message) error {
+			timer.Stop()
+			defer timer.Reset(idle)
+			return next(ctx, msg)
+		}
+	}
+
+	err := consume(ctx, handler)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// withShutdownSignals returns a context cancelled on the first SIGINT or SIGTERM
+// so commands can shut down gracefully. A second signal exits immediately,
+// giving operators an escape hatch when graceful shutdown hangs. Signals are
+// logged through the app logger once a command attaches it with
+// logShutdownSignals, and written to out before that.
+func withShutdownSignals(parent context.Context, out io.Writer) (context.Context, context.CancelFunc) {
+	notices := &shutdownLog{fallback: out}
+	ctx, cancel := context.WithCancel(context.WithValue(parent, shutdownLogKey{}, notices))
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case sig := <-signals:
+			notices.received(sig, "shutting down gracefully (send again to force exit)")
+			cancel()
+		case <-done:
+			return
+		}
+
+		select {
+		case sig := <-signals:
+			notices.received(sig, "forcing exit")
+			os.Exit(exitCode(sig))
+		case <-done:
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		close(done)
+		cancel()
+	}
+}
+
+type shutdownLogKey struct{}
+
+// shutdownLog reports received signals. Until a command's app has built its
+// logger, which may never happen, notices go to the fallback writer.
+type shutdownLog struct {
+	fallback io.Writer
+	logger   atomic.Pointer[zap.Logger]
+}
+
+func (l *shutdownLog) received(sig os.Signal, action string) {
+	logger := l.logger.Load()
+	if logger == nil {
+		fmt.Fprintf(l.fallback, "received %s; %s\n", sig, action)
+		return
+	}
+	logger.Warn("received "+sig.String()+"; "+action, zap.String("signal", sig.String()))
+	_ = logger.Sync()
+}
+
+type shutdownLoggerParams struct {
+	fx.In
+
+	Logger *zap.Logger `optional:"true"`
+}
+
+// logShutdownSignals hands the app logger to the signal handler installed on
+// ctx, so shutdown notices land in the service logs.
+func logShutdownSignals(ctx context.Context) fx.Option {
+	return fx.Invoke(func(p shutdownLoggerParams) {
+		notices, ok := ctx.Value(shutdownLogKey{}).(*shutdownLog)
+		if ok && p.Logger != nil {
+			notices.logger.Store(p.Logger)
+		}
+	})
+}
+
+// exitCode follows the shell convention of 128 + signal number.
+func exitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+	return 1
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestShutdownSignalLoggedThroughAppLogger(t *testing.T) {
+	var fallback bytes.Buffer
+	ctx, stop := withShutdownSignals(context.Background(), &fallback)
+	defer stop()
+
+	core, logs := observer.New(zap.DebugLevel)
+	app := fx.New(fx.NopLogger, fx.Supply(zap.New(core)), logShutdownSignals(ctx))
+	if err := app.Err(); err != nil {
+		t.Fatalf("build app: %v", err)
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatalf("send SIGTERM: %v", err)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("context not cancelled after SIGTERM")
+	}
+
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].ContextMap()["signal"] != syscall.SIGTERM.String() {
+		t.Fatalf("logged %v, want one entry for %s", entries, syscall.SIGTERM)
+	}
+	if fallback.Len() != 0 {
+		t.Fatalf("fallback got %q, want nothing once the logger is attached", fallback.String())
+	}
+}
+
+func TestShutdownSignalFallsBackBeforeLoggerExists(t *testing.T) {
+	var fallback bytes.Buffer
+	notices := &shutdownLog{fallback: &fallback}
+
+	notices.received(syscall.SIGINT, "shutting down gracefully")
+
+	if got := fallback.String(); !strings.Contains(got, syscall.SIGINT.String()) {
+		t.Fatalf("fallback = %q, want it to name the signal", got)
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	"github.com/Additional-Code/atlas/internal/observability"
+)
+
+func newWorkerTailCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Print messages arriving on a topic until interrupted",
+		Long: "Reads every partition without a consumer group, so no offsets are committed " +
+			"and nothing is retried, dead-lettered, or published. Values are printed as stored, " +
+			"without decoding. Only messages published after start are shown unless " +
+			"--from-beginning is set.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			topic, _ := cmd.Flags().GetString("topic")
+			fromBeginning, _ := cmd.Flags().GetBool("from-beginning")
+
+			var client messaging.Client
+			opts := fx.Options(
+				config.Module,
+				logger.Module,
+				observability.Module,
+				messaging.Module,
+				fx.Populate(&client),
+			)
+			return runWithApp(cmd.Context(), opts, func(ctx context.Context) error {
+				tailer, ok := client.(messaging.Tailer)
+				if !ok {
+					return errors.New("tail needs a broker-backed messaging driver")
+				}
+				if topic == "" {
+					topic = client.Topic()
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "tailing %s (ctrl+c to stop)\n", topic)
+				return tailMessages(ctx, tailer, topic, fromBeginning, cmd.OutOrStdout())
+			})
+		},
+	}
+	cmd.Flags().String("topic", "", "Topic to tail (defaults to KAFKA_TOPIC)")
+	cmd.Flags().Bool("from-beginning", false, "Replay the topic from the earliest retained offset")
+	return cmd
+}
+
+// tailMessages prints every message on topic to out until ctx is cancelled.
+func tailMessages(ctx context.Context, tailer messaging.Tailer, topic string, fromBeginning bool, out io.Writer) error {
+	err := tailer.Tail(ctx, topic, fromBeginning, func(_ context.Context, msg messaging.Message) error {
+		return printMessage(out, msg)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+func printMessage(out io.Writer, msg messaging.Message) error {
+	fmt.Fprintf(out, "--- %s offset=%d time=%s\n", msg.Topic, msg.Offset, msg.Time.Format(time.RFC3339Nano))
+	fmt.Fprintf(out, "key: %s\n", msg.Key)
+
+	if len(msg.Headers) > 0 {
+		keys := make([]string, 0, len(msg.Headers))
+		for k := range msg.Headers {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fmt.Fprintln(out, "headers:")
+		for _, k := range keys {
+			fmt.Fprintf(out, "  %s: %s\n", k, msg.Headers[k])
+		}
+	}
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, msg.Value, "", "  "); err == nil {
+		_, err := fmt.Fprintf(out, "value:\n%s\n", pretty.String())
+		return err
+	}
+	_, err := fmt.Fprintf(out, "value: %s\n", msg.Value)
+	return err
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// fakeTailer delivers its messages to the handler, then blocks until the
+// context is cancelled like a broker reader would.
+type fakeTailer struct {
+	messages      []messaging.Message
+	cancel        context.CancelFunc
+	topic         string
+	fromBeginning bool
+}
+
+func (c *fakeTailer) Tail(ctx context.Context, topic string, fromBeginning bool, handler messaging.Handler) error {
+	c.topic, c.fromBeginning = topic, fromBeginning
+	for _, msg := range c.messages {
+		if err := handler(ctx, msg); err != nil {
+			return err
+		}
+	}
+	c.cancel()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestTailMessagesPrintsConsumedMessages(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tailer := &fakeTailer{cancel: cancel, messages: []messaging.Message{
+		{Topic: "orders", Offset: 7, Time: at, Key: []byte("order-1"), Value: []byte(`{"id":1}`), Headers: map[string]string{"b": "2", "a": "1"}},
+		{Topic: "orders", Offset: 8, Time: at, Key: []byte("order-2"), Value: []byte("not json")},
+	}}
+
+	var out bytes.Buffer
+	if err := tailMessages(ctx, tailer, "orders", true, &out); err != nil {
+		t.Fatalf("tailMessages returned %v on cancellation, want nil", err)
+	}
+	if tailer.topic != "orders" || !tailer.fromBeginning {
+		t.Fatalf("tailed %q from beginning %t, want orders from the beginning", tailer.topic, tailer.fromBeginning)
+	}
+
+	want := []string{
+		"--- orders offset=7 time=2026-01-02T03:04:05Z\n",
+		"key: order-1\n",
+		"headers:\n  a: 1\n  b: 2\n",
+		"value:\n{\n  \"id\": 1\n}\n",
+		"--- orders offset=8",
+		"value: not json\n",
+	}
+	printed := out.String()
+	for _, fragment := range want {
+		if !strings.Contains(printed, fragment) {
+			t.Errorf("output missing %q:\n%s", fragment, printed)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewRejectsDedupOnNoopCache(t *testing.T) {
+	t.Setenv("ATLAS_ENV_FILES", "testdata/none.env")
+	t.Setenv("WORKER_DEDUP_ENABLED", "true")
+	t.Setenv("CACHE_DRIVER", "noop")
+
+	_, err := New()
+	if err == nil || !strings.Contains(err.Error(), "WORKER_DEDUP_ENABLED") {
+		t.Fatalf("New() error = %v, want a WORKER_DEDUP_ENABLED error", err)
+	}
+}
+
+func TestNewRejectsLRUAsTieredL2(t *testing.T) {
+	t.Setenv("ATLAS_ENV_FILES", "testdata/none.env")
+	t.Setenv("CACHE_DRIVER", "tiered")
+	t.Setenv("CACHE_TIERED_L1_DRIVER", "memory")
+	t.Setenv("CACHE_TIERED_L2_DRIVER", "lru")
+
+	if _, err := New(); !errors.Is(err, ErrUnsupportedCacheDriver) {
+		t.Fatalf("New() error = %v, want ErrUnsupportedCacheDriver", err)
+	}
+}
+
+// newWithEnv loads the config with env set on top of the defaults and no
+// dotenv files.
+func newWithEnv(t *testing.T, env map[string]string) (Config, error) {
+	t.Helper()
+	t.Setenv("ATLAS_ENV_FILES", "testdata/none.env")
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	return New()
+}
+
+func TestRedisModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+		check   func(t *testing.T, cfg Redis)
+	}{
+		{
+			name: "standalone default",
+			env:  map[string]string{"REDIS_ADDR": "cache:6379"},
+			check: func(t *testing.T, cfg Redis) {
+				if cfg.Mode != RedisModeStandalone || cfg.Addr != "cache:6379" {
+					t.Fatalf("redis = %+v, want standalone on cache:6379", cfg)
+				}
+			},
+		},
+		{
+			name:    "standalone without address",
+			env:     map[string]string{"REDIS_ADDR": ""},
+			wantErr: "REDIS_ADDR",
+		},
+		{
+			name: "sentinel",
+			env: map[string]string{
+				"REDIS_MODE":           " Sentinel ",
+				"REDIS_MASTER_NAME":    "mymaster",
+				"REDIS_SENTINEL_ADDRS": "s1:26379, s2:26379",
+			},
+			check: func(t *testing.T, cfg Redis) {
+				if cfg.Mode != RedisModeSentinel || cfg.MasterName != "mymaster" {
+					t.Fatalf("redis = %+v, want sentinel for mymaster", cfg)
+				}
+				if len(cfg.SentinelAddrs) != 2 || cfg.SentinelAddrs[1] != "s2:26379" {
+					t.Fatalf("sentinel addrs = %q, want [s1:26379 s2:26379]", cfg.SentinelAddrs)
+				}
+			},
+		},
+		{
+			name:    "sentinel without master",
+			env:     map[string]string{"REDIS_MODE": "sentinel", "REDIS_SENTINEL_ADDRS": "s1:26379"},
+			wantErr: "REDIS_MASTER_NAME",
+		},
+		{
+			name:    "sentinel without addresses",
+			env:     map[string]string{"REDIS_MODE": "sentinel", "REDIS_MASTER_NAME": "mymaster"},
+			wantErr: "REDIS_SENTINEL_ADDRS",
+		},
+		{
+			name: "cluster",
+			env:  map[string]string{"REDIS_MODE": "cluster", "REDIS_CLUSTER_ADDRS": "c1:7000,c2:7000,c3:7000"},
+			check: func(t *testing.T, cfg Redis) {
+				if cfg.Mode != RedisModeCluster || len(cfg.ClusterAddrs) != 3 {
+					t.Fatalf("redis = %+v, want cluster with 3 addrs", cfg)
+				}
+			},
+		},
+		{
+			name:    "cluster without addresses",
+			env:     map[string]string{"REDIS_MODE": "cluster"},
+			wantErr: "REDIS_CLUSTER_ADDRS",
+		},
+		{
+			name:    "unknown mode",
+			env:     map[string]string{"REDIS_MODE": "ring"},
+			wantErr: "REDIS_MODE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"CACHE_DRIVER": "redis"}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			cfg, err := newWithEnv(t, env)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("New() error = %v, want one naming %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New(): %v", err)
+			}
+			tt.check(t, cfg.Cache.Redis)
+		})
+	}
+}
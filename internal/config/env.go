@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// loadEnvFiles applies dotenv files in order, later files overriding earlier
+// ones, while variables already set in the process environment win over all
+// of them. The list comes from ATLAS_ENV_FILES, defaulting to ".env", then
+// ".env.local", then ".env.<OBS_ENVIRONMENT>" when set. Missing files are
+// skipped; files that exist but fail to load are skipped and recorded in
+// envFileErrs.
+func loadEnvFiles() {
+	merged := make(map[string]string)
+	for _, file := range envFiles() {
+		values, err := godotenv.Read(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			envFileErrs = append(envFileErrs, fmt.Errorf("load %s: %w", file, err))
+			continue
+		}
+		for key, value := range values {
+			merged[key] = value
+		}
+	}
+
+	for key, value := range merged {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		_ = os.Setenv(key, value)
+	}
+}
+
+// envFileErrs holds the dotenv files that were present but failed to load.
+var envFileErrs []error
+
+// EnvFileErrors reports dotenv files that exist but could not be loaded, so
+// the logger can warn about them once it is built. Their variables are not
+// applied.
+func EnvFileErrors() []error {
+	loadEnvOnce.Do(loadEnvFiles)
+	return envFileErrs
+}
+
+func envFiles() []string {
+	if files := getEnvAsStringSlice("ATLAS_ENV_FILES", nil); len(files) > 0 {
+		return files
+	}
+
+	// The profile may itself be declared in the base file.
+	profile, ok := os.LookupEnv("OBS_ENVIRONMENT")
+	if !ok {
+		if base, err := godotenv.Read(".env"); err == nil {
+			profile = base["OBS_ENVIRONMENT"]
+		}
+	}
+	files := []string{".env", ".env.local"}
+	if profile = strings.TrimSpace(profile); profile != "" && profile != "local" {
+		files = append(files, ".env."+profile)
+	}
+	return files
+}
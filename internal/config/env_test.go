@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestEnvFilesOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		base    string
+		want    []string
+	}{
+		{name: "no profile", want: []string{".env", ".env.local"}},
+		{name: "local profile", profile: "local", want: []string{".env", ".env.local"}},
+		{name: "process profile", profile: "staging", want: []string{".env", ".env.local", ".env.staging"}},
+		{name: "profile from .env", base: "OBS_ENVIRONMENT=production\n", want: []string{".env", ".env.local", ".env.production"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			t.Setenv("ATLAS_ENV_FILES", "")
+			if tt.profile != "" {
+				t.Setenv("OBS_ENVIRONMENT", tt.profile)
+			} else {
+				unsetEnv(t, "OBS_ENVIRONMENT")
+			}
+			if tt.base != "" {
+				if err := os.WriteFile(".env", []byte(tt.base), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := envFiles(); !slices.Equal(got, tt.want) {
+				t.Fatalf("envFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// unsetEnv removes key for the rest of the test, restoring it afterwards.
+func unsetEnv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	if err := os.Unsetenv(key); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadEnvFilesPrecedence(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("ATLAS_ENV_FILES", "")
+	t.Setenv("OBS_ENVIRONMENT", "staging")
+	unsetEnv(t, "ATLAS_TEST_BASE")
+	unsetEnv(t, "ATLAS_TEST_PROFILE")
+	t.Setenv("ATLAS_TEST_PROCESS", "process")
+
+	base := "ATLAS_TEST_BASE=base\nATLAS_TEST_PROFILE=base\nATLAS_TEST_PROCESS=base\n"
+	profile := "ATLAS_TEST_PROFILE=staging\nATLAS_TEST_PROCESS=staging\n"
+	if err := os.WriteFile(".env", []byte(base), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(".env.staging", []byte(profile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	loadEnvFiles()
+
+	want := map[string]string{
+		"ATLAS_TEST_BASE":    "base",
+		"ATLAS_TEST_PROFILE": "staging",
+		"ATLAS_TEST_PROCESS": "process",
+	}
+	for key, value := range want {
+		if got := os.Getenv(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnsupportedCacheDriver reports a CACHE_DRIVER outside the supported set.
+	ErrUnsupportedCacheDriver = errors.New("unsupported cache driver")
+	// ErrUnsupportedMessagingDriver reports a MESSAGING_DRIVER outside the supported set.
+	ErrUnsupportedMessagingDriver = errors.New("unsupported messaging driver")
+)
+
+// UnsupportedDriverError carries the offending driver value while matching its
+// sentinel via errors.Is.
+type UnsupportedDriverError struct {
+	kind   error
+	driver string
+}
+
+// NewUnsupportedDriverError wraps kind with the rejected driver name.
+func NewUnsupportedDriverError(kind error, driver string) *UnsupportedDriverError {
+	return &UnsupportedDriverError{kind: kind, driver: driver}
+}
+
+// Error satisfies the error interface.
+func (e *UnsupportedDriverError) Error() string {
+	return fmt.Sprintf("%v: %s", e.kind, e.driver)
+}
+
+// Unwrap exposes the sentinel for errors.Is.
+func (e *UnsupportedDriverError) Unwrap() error {
+	return e.kind
+}
+
+// Driver returns the rejected driver value.
+func (e *UnsupportedDriverError) Driver() string {
+	return e.driver
+}
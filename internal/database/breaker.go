@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// ErrCircuitOpen is returned without touching the database while the breaker is open.
+var ErrCircuitOpen = errors.New("database circuit open")
+
+// BreakerState is the breaker position; values are exported as the db.circuit.state metric.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerHalfOpen
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half_open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker sheds database load after consecutive failures. Once open it fails
+// fast until the cooldown elapses, then lets a single probe through: success
+// closes it, failure re-opens it for another cooldown.
+type Breaker struct {
+	enabled   bool
+	threshold int
+	cooldown  time.Duration
+	logger    *zap.Logger
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	// generation advances on every state change, so outcomes of calls
+	// admitted under an earlier state are ignored.
+	generation uint64
+}
+
+// admission records the state a call was let through under.
+type admission struct {
+	generation uint64
+	probe      bool
+}
+
+// outcome is how a finished call affects the breaker.
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// outcomeNeutral says nothing about database health, e.g. the caller
+	// cancelled.
+	outcomeNeutral
+)
+
+// NewBreaker builds the breaker shared by repositories and registers its state gauge.
+func NewBreaker(cfg config.Config, logger *zap.Logger) (*Breaker, error) {
+	b := &Breaker{
+		enabled:   cfg.Database.Breaker.Enabled,
+		threshold: cfg.Database.Breaker.FailureThreshold,
+		cooldown:  cfg.Database.Breaker.Cooldown,
+		logger:    logger,
+	}
+
+	_, err := otel.Meter("github.com/Additional-Code/atlas/database").Int64ObservableGauge("db.circuit.state",
+		metric.WithDescription("Database circuit breaker state (0 closed, 1 half-open, 2 open)"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(int64(b.State()))
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// State reports the current breaker position.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Do runs fn unless the breaker is open, recording its outcome. Only
+// connection, timeout, and server-side errors count as database failures;
+// constraint violations and missing rows count as successes. A caller
+// cancellation counts as neither, and a cancelled probe returns the breaker
+// to open so the next call probes again. Outcomes of calls admitted before the
+// last state change are ignored. If fn panics the outcome is recorded as a
+// failure before the panic continues, so a half-open probe never leaves the
+// breaker stuck.
+func (b *Breaker) Do(ctx context.Context, fn func(context.Context) error) (err error) {
+	if b == nil || !b.enabled {
+		return fn(ctx)
+	}
+	ticket, ok := b.allow()
+	if !ok {
+		return ErrCircuitOpen
+	}
+	completed := false
+	defer func() {
+		if !completed {
+			b.record(ticket, outcomeFailure)
+		}
+	}()
+	err = fn(ctx)
+	completed = true
+	b.record(ticket, classify(err))
+	return err
+}
+
+func classify(err error) outcome {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return outcomeNeutral
+	case isDatabaseFailure(err):
+		return outcomeFailure
+	default:
+		return outcomeSuccess
+	}
+}
+
+func (b *Breaker) allow() (admission, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return admission{}, false
+		}
+		b.transition(BreakerHalfOpen)
+		return admission{generation: b.generation, probe: true}, true
+	case BreakerHalfOpen:
+		// A probe is already in flight.
+		return admission{}, false
+	default:
+		return admission{generation: b.generation}, true
+	}
+}
+
+func (b *Breaker) record(ticket admission, result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ticket.generation != b.generation {
+		return
+	}
+
+	switch result {
+	case outcomeNeutral:
+		// openedAt is kept, so the cooldown has already elapsed and the next
+		// call becomes the probe.
+		if ticket.probe {
+			b.transition(BreakerOpen)
+		}
+	case outcomeSuccess:
+		b.failures = 0
+		if b.state != BreakerClosed {
+			b.transition(BreakerClosed)
+		}
+	default:
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.openedAt = time.Now()
+			if b.state != BreakerOpen {
+				b.transition(BreakerOpen)
+			}
+		}
+	}
+}
+
+// transition must be called with mu held.
+func (b *Breaker) transition(to BreakerState) {
+	b.logger.Warn("database circuit breaker state changed",
+		zap.Stringer("from", b.state),
+		zap.Stringer("to", to),
+		zap.Int("failures", b.failures),
+	)
+	b.state = to
+	b.generation++
+}
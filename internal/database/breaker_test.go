@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func newTestBreaker(t *testing.T, threshold int, cooldown time.Duration) *Breaker {
+	t.Helper()
+	var cfg config.Config
+	cfg.Database.Breaker = config.Breaker{Enabled: true, FailureThreshold: threshold, Cooldown: cooldown}
+	b, err := NewBreaker(cfg, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewBreaker: %v", err)
+	}
+	return b
+}
+
+func failWith(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestBreakerOpensOnSustainedFailuresAndRecovers(t *testing.T) {
+	b := newTestBreaker(t, 3, 20*time.Millisecond)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_ = b.Do(ctx, failWith(context.DeadlineExceeded))
+	}
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("state after failures = %s, want open", got)
+	}
+	called := false
+	if err := b.Do(ctx, func(context.Context) error { called = true; return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Do while open = %v, want ErrCircuitOpen", err)
+	}
+	if called {
+		t.Fatal("fn ran while the circuit was open")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if err := b.Do(ctx, failWith(nil)); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("state after successful probe = %s, want closed", got)
+	}
+}
+
+func TestBreakerIgnoresIntegrityViolations(t *testing.T) {
+	b := newTestBreaker(t, 2, time.Minute)
+	duplicate := &mysql.MySQLError{Number: 1062, SQLState: [5]byte{'2', '3', '0', '0', '0'}, Message: "Duplicate entry"}
+
+	for i := 0; i < 5; i++ {
+		_ = b.Do(context.Background(), failWith(duplicate))
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("state after constraint violations = %s, want closed", got)
+	}
+}
+
+func TestBreakerPanickingProbeReopens(t *testing.T) {
+	b := newTestBreaker(t, 1, 10*time.Millisecond)
+	_ = b.Do(context.Background(), failWith(context.DeadlineExceeded))
+	time.Sleep(20 * time.Millisecond)
+
+	func() {
+		defer func() { _ = recover() }()
+		_ = b.Do(context.Background(), func(context.Context) error { panic("boom") })
+	}()
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("state after panicking probe = %s, want open", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if err := b.Do(context.Background(), failWith(nil)); err != nil {
+		t.Fatalf("probe after cooldown: %v", err)
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("state = %s, want closed", got)
+	}
+}
+
+func TestBreakerCancelledProbeReturnsToOpen(t *testing.T) {
+	b := newTestBreaker(t, 1, 10*time.Millisecond)
+	_ = b.Do(context.Background(), failWith(context.DeadlineExceeded))
+	time.Sleep(20 * time.Millisecond)
+
+	if err := b.Do(context.Background(), failWith(context.Canceled)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled probe = %v, want context.Canceled", err)
+	}
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("state after cancelled probe = %s, want open", got)
+	}
+
+	// The cooldown already elapsed, so the next call probes straight away.
+	if err := b.Do(context.Background(), failWith(nil)); err != nil {
+		t.Fatalf("next probe: %v", err)
+	}
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("state after successful probe = %s, want closed", got)
+	}
+}
+
+func TestBreakerIgnoresOutcomesFromEarlierStates(t *testing.T) {
+	b := newTestBreaker(t, 1, 10*time.Millisecond)
+	ctx := context.Background()
+
+	// start runs a call that blocks until its result is sent.
+	start := func() (chan<- error, <-chan struct{}) {
+		result, done := make(chan error), make(chan struct{})
+		admitted := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = b.Do(ctx, func(context.Context) error {
+				close(admitted)
+				return <-result
+			})
+		}()
+		<-admitted
+		return result, done
+	}
+
+	slowSuccess, successDone := start()
+	slowFailure, failureDone := start()
+	_ = b.Do(ctx, failWith(context.DeadlineExceeded))
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	slowSuccess <- nil
+	<-successDone
+	if got := b.State(); got != BreakerOpen {
+		t.Fatalf("state after a call admitted while closed succeeded = %s, want open", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	probe, probeDone := start()
+	slowFailure <- context.DeadlineExceeded
+	<-failureDone
+	if got := b.State(); got != BreakerHalfOpen {
+		t.Fatalf("state after a call admitted while closed failed = %s, want half_open", got)
+	}
+
+	probe <- nil
+	<-probeDone
+	if got := b.State(); got != BreakerClosed {
+		t.Fatalf("state after successful probe = %s, want closed", got)
+	}
+}
@@ -18,16 +18,20 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/health"
 )
 
 // Connections bundles writer and reader bun instances.
 type Connections struct {
 	Writer *bun.DB
 	Reader *bun.DB
+	// txRetry governs RunInTx retries after serialization failures.
+	txRetry config.TxRetry
 }
 
-// Module registers the database connections with Fx.
-var Module = fx.Provide(New)
+// Module registers the database connections, circuit breaker, and readiness
+// check with Fx.
+var Module = fx.Provide(New, NewBreaker, health.AsHealthCheck(newHealthCheck))
 
 // New establishes writer and reader pools backed by Bun.
 func New(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (*Connections, error) {
@@ -57,7 +61,18 @@ func New(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (*Connections,
 		reader = writer
 	}
 
-	conns := &Connections{Writer: writer, Reader: reader}
+	// Bind parameters may hold PII, so they are only logged when explicitly
+	// requested and the logger is at debug level.
+	if cfg.Database.LogArgs && logger.Core().Enabled(zap.DebugLevel) {
+		hook := newQueryLogHook(logger, cfg.Database.RedactColumns)
+		writer.AddQueryHook(hook)
+		if reader != writer {
+			reader.AddQueryHook(hook)
+		}
+		logger.Info("sql argument logging enabled", zap.Strings("redacted_columns", cfg.Database.RedactColumns))
+	}
+
+	conns := &Connections{Writer: writer, Reader: reader, txRetry: cfg.Database.TxRetry}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -132,6 +147,15 @@ func applyPoolSettings(db *sql.DB, cfg config.Database) {
 	}
 }
 
+// HealthCheck pings the writer, which every write depends on.
+func (c *Connections) HealthCheck(ctx context.Context) error {
+	return pingContext(ctx, c.Writer)
+}
+
+func newHealthCheck(conns *Connections) health.HealthCheck {
+	return health.HealthCheck{Name: "database", Check: conns.HealthCheck}
+}
+
 func pingContext(ctx context.Context, db *bun.DB) error {
 	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
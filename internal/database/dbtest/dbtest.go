@@ -0,0 +1,47 @@
+// Package dbtest provides throwaway SQLite databases for tests.
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+
+	"github.com/Additional-Code/atlas/internal/database"
+)
+
+var counter atomic.Int64
+
+// New opens a private in-memory SQLite database closed when t ends. It keeps a
+// single connection so every query sees the same database.
+func New(t testing.TB) *bun.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:dbtest%d?mode=memory&cache=shared", counter.Add(1))
+	sqldb, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqldb.SetMaxOpenConns(1)
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// Connections wraps db as both writer and reader.
+func Connections(db *bun.DB) *database.Connections {
+	return &database.Connections{Writer: db, Reader: db}
+}
+
+// CreateTables creates a table for each model, failing t on error.
+func CreateTables(t testing.TB, db *bun.DB, models ...any) {
+	t.Helper()
+	for _, model := range models {
+		if _, err := db.NewCreateTable().Model(model).Exec(t.Context()); err != nil {
+			t.Fatalf("create table for %T: %v", model, err)
+		}
+	}
+}
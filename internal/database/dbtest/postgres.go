@@ -0,0 +1,48 @@
+//go:build integration
+
+package dbtest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// PostgresDSNEnv names the variable holding the Postgres DSN integration tests
+// connect to.
+const PostgresDSNEnv = "ATLAS_TEST_POSTGRES_DSN"
+
+// Postgres opens a Postgres database in a private schema dropped when t ends,
+// so tests may create tables freely. It skips t when PostgresDSNEnv is unset.
+func Postgres(t testing.TB) *bun.DB {
+	t.Helper()
+	dsn := os.Getenv(PostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s not set", PostgresDSNEnv)
+	}
+
+	admin := bun.NewDB(sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn))), pgdialect.New())
+	defer admin.Close()
+	schema := fmt.Sprintf("atlas_test_%d_%d", os.Getpid(), counter.Add(1))
+	if _, err := admin.ExecContext(t.Context(), "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	sqldb := sql.OpenDB(pgdriver.NewConnector(
+		pgdriver.WithDSN(dsn),
+		pgdriver.WithConnParams(map[string]interface{}{"search_path": schema}),
+	))
+	db := bun.NewDB(sqldb, pgdialect.New())
+	t.Cleanup(func() {
+		_ = db.Close()
+		cleanup := bun.NewDB(sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn))), pgdialect.New())
+		defer cleanup.Close()
+		_, _ = cleanup.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+	return db
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// sqlStateIntegrityViolation is the SQLSTATE class of constraint violations
+// (unique, foreign key, not null, check).
+const sqlStateIntegrityViolation = "23"
+
+// sqlStateServerClasses are the SQLSTATE classes that signal an unhealthy
+// server rather than a bad statement: connection exceptions, insufficient
+// resources, operator intervention (e.g. shutdown), system and internal errors.
+var sqlStateServerClasses = []string{"08", "53", "57", "58", "XX"}
+
+// sqlState returns the SQLSTATE a Postgres or MySQL error carries.
+func sqlState(err error) (string, bool) {
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Field('C'), true
+	}
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return string(myErr.SQLState[:]), true
+	}
+	return "", false
+}
+
+// IsIntegrityViolation reports whether err is a constraint violation
+// (SQLSTATE class 23), e.g. a duplicate key.
+func IsIntegrityViolation(err error) bool {
+	state, ok := sqlState(err)
+	return ok && strings.HasPrefix(state, sqlStateIntegrityViolation)
+}
+
+// isDatabaseFailure reports whether err says the database is unhealthy:
+// a connection or timeout error, or a server-side SQLSTATE. Errors caused by
+// the statement or its data, such as constraint violations, missing rows, and
+// caller cancellations, are not failures.
+func isDatabaseFailure(err error) bool {
+	if err == nil || errors.Is(err, sql.ErrNoRows) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if state, ok := sqlState(err); ok {
+		for _, class := range sqlStateServerClasses {
+			if strings.HasPrefix(state, class) {
+				return true
+			}
+		}
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.As(err, &netErr)
+}
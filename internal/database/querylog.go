@@ -0,0 +1,313 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+const redactedValue = "[REDACTED]"
+
+// queryLogHook logs every query at debug level together with its bind
+// parameters. Values of flagged columns are replaced before logging.
+type queryLogHook struct {
+	logger *zap.Logger
+	redact map[string]struct{}
+	// mentions matches a flagged column name inside a query template.
+	mentions *regexp.Regexp
+}
+
+func newQueryLogHook(logger *zap.Logger, columns []string) *queryLogHook {
+	h := &queryLogHook{logger: logger, redact: make(map[string]struct{}, len(columns))}
+	quoted := make([]string, 0, len(columns))
+	for _, column := range columns {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if column == "" {
+			continue
+		}
+		h.redact[column] = struct{}{}
+		quoted = append(quoted, regexp.QuoteMeta(column))
+	}
+	if len(quoted) > 0 {
+		h.mentions = regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return h
+}
+
+func (h *queryLogHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *queryLogHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	query, args := h.describe(event)
+	fields := []zap.Field{
+		zap.String("operation", event.Operation()),
+		zap.String("query", query),
+		zap.Any("args", args),
+		zap.Duration("duration", time.Since(event.StartTime)),
+	}
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		fields = append(fields, zap.Error(event.Err))
+	}
+	h.logger.Debug("sql query", fields...)
+}
+
+// describe returns the query with placeholders instead of inlined values and
+// the bind parameters that fill them.
+func (h *queryLogHook) describe(event *bun.QueryEvent) (string, []any) {
+	if event.IQuery == nil {
+		return event.QueryTemplate, h.positionalArgs(event.QueryTemplate, event.QueryArgs)
+	}
+
+	// Builder queries inline their arguments, so they are read back out of
+	// the rendered query and redacted by the column each one is bound to.
+	query, values := splitLiterals(event.Query)
+	if len(values) == 0 {
+		return query, nil
+	}
+	mentioned := h.mentions != nil && h.mentions.MatchString(query)
+	args := make([]any, len(values))
+	for i, v := range values {
+		switch {
+		case v.column != "":
+			args[i] = h.redactValue(v.column, v.value)
+		case mentioned:
+			args[i] = redactedValue
+		default:
+			args[i] = v.value
+		}
+	}
+	return query, args
+}
+
+// positionalArgs cannot tie raw query arguments to columns, so all of them
+// are redacted when the template mentions any flagged column.
+func (h *queryLogHook) positionalArgs(template string, args []any) []any {
+	if len(args) == 0 {
+		return nil
+	}
+	out := append([]any(nil), args...)
+	if h.mentions != nil && h.mentions.MatchString(template) {
+		for i := range out {
+			out[i] = redactedValue
+		}
+	}
+	return out
+}
+
+func (h *queryLogHook) redactValue(column string, value any) any {
+	if _, ok := h.redact[strings.ToLower(column)]; ok {
+		return redactedValue
+	}
+	return value
+}
+
+// boundValue is a literal read out of a rendered query. column is empty when
+// the SQL around the literal does not say which column it is bound to.
+type boundValue struct {
+	column string
+	value  any
+}
+
+// sqlGroup tracks one parenthesised group while a query is scanned.
+type sqlGroup struct {
+	columns []string // bound by position, for VALUES rows and tuple comparisons
+	column  string   // bound by every item, for IN lists
+	idents  []string // last identifier of each item, in case the group is a column list
+	item    int
+}
+
+func (g *sqlGroup) columnFor(bound string) string {
+	switch {
+	case bound != "":
+		return bound
+	case g.item < len(g.columns):
+		return g.columns[g.item]
+	default:
+		return g.column
+	}
+}
+
+// splitLiterals replaces every string and number literal in query with a
+// placeholder and returns the literals in order. Each literal is tied to the
+// column it is compared with, assigned to, inserted into, or listed for.
+func splitLiterals(query string) (string, []boundValue) {
+	var (
+		out      strings.Builder
+		values   []boundValue
+		groups   = []*sqlGroup{{}}
+		prev     string   // kind of the previous token
+		ident    string   // last identifier
+		bound    string   // column the next literal is compared with
+		inColumn string   // column in front of the last IN
+		closed   []string // identifiers of the group closed last
+		inserted []string // column list in front of VALUES
+		afterTup bool     // the last operator follows a closed group
+		valuesAt = -1     // depth of the VALUES rows being read
+	)
+	out.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		top := groups[len(groups)-1]
+		j := i + 1
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '.' || c == ':':
+			// Whitespace, qualified names, and casts do not change the context.
+			out.WriteByte(c)
+			i = j
+			continue
+
+		case c == '\'':
+			for j < len(query) && (query[j] != '\'' || j+1 < len(query) && query[j+1] == '\'') {
+				if query[j] == '\'' {
+					j++
+				}
+				j++
+			}
+			text := strings.ReplaceAll(query[i+1:min(j, len(query))], "''", "'")
+			values = append(values, boundValue{column: top.columnFor(bound), value: text})
+			out.WriteByte('?')
+			prev, bound = "literal", ""
+			i = min(j+1, len(query))
+			continue
+
+		case prev == "LIMIT" || prev == "OFFSET":
+			// Paging bounds are part of the statement, not bind values.
+			for j < len(query) && isDigit(query[j]) {
+				j++
+			}
+			prev = "literal"
+
+		case isDigit(c) || c == '-' && j < len(query) && isDigit(query[j]) && prev != "ident" && prev != "literal" && prev != ")":
+			for j < len(query) && (isDigit(query[j]) || query[j] == '.' || query[j] == 'e' || query[j] == 'E' ||
+				(query[j] == '+' || query[j] == '-') && (query[j-1] == 'e' || query[j-1] == 'E')) {
+				j++
+			}
+			values = append(values, boundValue{column: top.columnFor(bound), value: parseNumber(query[i:j])})
+			out.WriteByte('?')
+			prev, bound = "literal", ""
+			i = j
+			continue
+
+		case c == '"':
+			for j < len(query) && (query[j] != '"' || j+1 < len(query) && query[j+1] == '"') {
+				if query[j] == '"' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(query))
+			ident = strings.ReplaceAll(strings.Trim(query[i:j], `"`), `""`, `"`)
+			top.setIdent(ident)
+			prev, bound = "ident", ""
+
+		case isWordStart(c):
+			for j < len(query) && (isWordStart(query[j]) || isDigit(query[j]) || query[j] == '$') {
+				j++
+			}
+			switch word := strings.ToUpper(query[i:j]); word {
+			case "NOT":
+				// Transparent, so "email NOT LIKE" reads like "email LIKE".
+			case "LIKE", "ILIKE":
+				if prev == "ident" {
+					bound = ident
+				}
+				prev = "op"
+			case "IN":
+				inColumn = ""
+				if prev == "ident" {
+					inColumn = ident
+				}
+				prev, bound = word, ""
+			case "VALUES":
+				valuesAt, inserted = len(groups)-1, closed
+				prev, bound = word, ""
+			case "AND", "OR", "IS", "NULL", "DEFAULT", "TRUE", "FALSE", "BETWEEN", "LIMIT", "OFFSET":
+				prev, bound = word, ""
+			default:
+				if valuesAt == len(groups)-1 {
+					valuesAt = -1
+				}
+				ident = query[i:j]
+				top.setIdent(ident)
+				prev, bound = "ident", ""
+			}
+
+		case c == '=' || c == '<' || c == '>' || c == '!':
+			for j < len(query) && strings.IndexByte("=<>!", query[j]) >= 0 {
+				j++
+			}
+			afterTup = prev == ")"
+			bound = ""
+			if prev == "ident" {
+				bound = ident
+			}
+			prev = "op"
+
+		case c == '(':
+			group := &sqlGroup{}
+			switch {
+			case prev == "IN":
+				group.column = inColumn
+			case prev == "VALUES" || prev == "," && valuesAt == len(groups)-1:
+				group.columns = inserted
+			case prev == "op" && afterTup:
+				group.columns = closed
+			case prev == "op":
+				group.column = bound
+			}
+			groups = append(groups, group)
+			prev, bound = "(", ""
+
+		case c == ')':
+			if len(groups) > 1 {
+				closed = top.idents
+				groups = groups[:len(groups)-1]
+			}
+			prev, bound = ")", ""
+
+		case c == ',':
+			top.item++
+			prev, bound = ",", ""
+
+		default:
+			prev, bound = string(c), ""
+		}
+
+		out.WriteString(query[i:j])
+		i = j
+	}
+	return out.String(), values
+}
+
+func (g *sqlGroup) setIdent(name string) {
+	for len(g.idents) <= g.item {
+		g.idents = append(g.idents, "")
+	}
+	g.idents[g.item] = name
+}
+
+func parseNumber(text string) any {
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return n
+	}
+	if f, err := strconv.ParseFloat(text, 64); err == nil {
+		return f
+	}
+	return text
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isWordStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
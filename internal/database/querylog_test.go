@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	_ "modernc.org/sqlite"
+)
+
+type queryLogCustomer struct {
+	bun.BaseModel `bun:"table:customers"`
+
+	ID    int64  `bun:",pk,autoincrement"`
+	Name  string `bun:"name"`
+	Email string `bun:"email"`
+}
+
+func newQueryLogDB(t *testing.T) (*bun.DB, *observer.ObservedLogs) {
+	t.Helper()
+	sqldb, err := sql.Open("sqlite", "file:querylog?mode=memory")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqldb.SetMaxOpenConns(1)
+	db := bun.NewDB(sqldb, sqlitedialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err := db.NewCreateTable().Model((*queryLogCustomer)(nil)).Exec(context.Background()); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	core, logs := observer.New(zap.DebugLevel)
+	db.AddQueryHook(newQueryLogHook(zap.New(core), []string{"Email"}))
+	return db, logs
+}
+
+func TestQueryLogRedactsFlaggedModelColumn(t *testing.T) {
+	db, logs := newQueryLogDB(t)
+
+	customer := &queryLogCustomer{Name: "Ada", Email: "ada@example.com"}
+	if _, err := db.NewInsert().Model(customer).Exec(context.Background()); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	entries := logs.FilterMessage("sql query").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d queries, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if query := fields["query"].(string); strings.Contains(query, "ada@example.com") {
+		t.Fatalf("query %q leaks the flagged value", query)
+	}
+	args := fields["args"].([]any)
+	if len(args) != 2 || args[0] != "Ada" || args[1] != redactedValue {
+		t.Errorf("args = %v, want [Ada %s]", args, redactedValue)
+	}
+}
+
+func TestQueryLogRedactsFlaggedColumnInWhereAndSet(t *testing.T) {
+	db, logs := newQueryLogDB(t)
+	ctx := context.Background()
+
+	var customers []queryLogCustomer
+	if err := db.NewSelect().Model(&customers).
+		Where("email = ?", "ada@example.com").
+		Where("name = ?", "Ada").
+		Scan(ctx); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if _, err := db.NewUpdate().Model((*queryLogCustomer)(nil)).
+		Set("email = ?", "grace@example.com").
+		Where("id = ?", 7).
+		Exec(ctx); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	entries := logs.FilterMessage("sql query").All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d queries, want 2", len(entries))
+	}
+	want := [][]any{{redactedValue, "Ada"}, {redactedValue, int64(7)}}
+	for i, entry := range entries {
+		fields := entry.ContextMap()
+		if query := fields["query"].(string); strings.Contains(query, "@example.com") {
+			t.Fatalf("query %q leaks the flagged value", query)
+		}
+		args, _ := fields["args"].([]any)
+		if !reflect.DeepEqual(args, want[i]) {
+			t.Errorf("%s args = %v, want %v", fields["operation"], args, want[i])
+		}
+	}
+}
+
+func TestQueryLogRedactsRawQueryArgsMentioningFlaggedColumn(t *testing.T) {
+	db, logs := newQueryLogDB(t)
+	ctx := context.Background()
+
+	if _, err := db.ExecContext(ctx, "UPDATE customers SET email = ? WHERE id = ?", "ada@example.com", 1); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE customers SET name = ? WHERE id = ?", "Ada", 1); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	entries := logs.FilterMessage("sql query").All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d queries, want 2", len(entries))
+	}
+	if args := entries[0].ContextMap()["args"].([]any); args[0] != redactedValue || args[1] != redactedValue {
+		t.Errorf("args = %v, want all redacted", args)
+	}
+	if args := entries[1].ContextMap()["args"].([]any); args[0] != "Ada" {
+		t.Errorf("args = %v, want them logged for a query without flagged columns", args)
+	}
+}
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+type readRoute int
+
+const (
+	routeDefault readRoute = iota
+	routePrimary
+	routeReplica
+)
+
+type readRouteKey struct{}
+
+// WithForcePrimary routes reads made with ctx to the writer, for flows that
+// must observe their own writes despite replica lag.
+func WithForcePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readRouteKey{}, routePrimary)
+}
+
+// WithForceReplica routes reads made with ctx to the reader, for heavy
+// queries that tolerate lag. Both overrides share one context slot, so the
+// one applied last wins: WithForceReplica(WithForcePrimary(ctx)) reads from
+// the replica and vice versa.
+func WithForceReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readRouteKey{}, routeReplica)
+}
+
+// ReadDB returns the connection reads made with ctx should use: the reader by
+// default or when forced to the replica, the writer when forced to primary.
+func (c *Connections) ReadDB(ctx context.Context) *bun.DB {
+	if route, _ := ctx.Value(readRouteKey{}).(readRoute); route == routePrimary {
+		return c.Writer
+	}
+	return c.Reader
+}
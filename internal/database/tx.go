@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// sqlStateSerializationFailure is the SQLSTATE a database returns when a
+// transaction cannot be serialized with concurrent ones and must be retried.
+const sqlStateSerializationFailure = "40001"
+
+// RunInTx runs fn in a writer transaction begun with opts (nil uses the
+// driver's default isolation), committing when fn returns nil. When the
+// transaction fails with a serialization failure it is retried from the start,
+// up to DB_TX_MAX_ATTEMPTS tries with doubling DB_TX_RETRY_BACKOFF waits, so
+// fn must be safe to run more than once: keep side effects outside the
+// database (publishing, caching) until RunInTx returns.
+func (c *Connections) RunInTx(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx bun.Tx) error) error {
+	attempts := max(c.txRetry.MaxAttempts, 1)
+	backoff := c.txRetry.Backoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = c.Writer.RunInTx(ctx, opts, fn)
+		if err == nil || attempt >= attempts || !IsSerializationFailure(err) {
+			return err
+		}
+
+		// Jitter keeps the transactions that just collided from retrying in step.
+		wait := backoff
+		if backoff > 0 {
+			wait += time.Duration(rand.Int64N(int64(backoff)))
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+// RunSerializable runs fn in a SERIALIZABLE writer transaction, retrying
+// serialization failures like RunInTx. Use it for read-then-write operations
+// that must not interleave, e.g. checking and updating a balance.
+func (c *Connections) RunSerializable(ctx context.Context, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return c.RunInTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable}, fn)
+}
+
+// IsSerializationFailure reports whether err is a Postgres or MySQL
+// serialization failure (SQLSTATE 40001), which is safe to retry.
+func IsSerializationFailure(err error) bool {
+	state, ok := sqlState(err)
+	return ok && state == sqlStateSerializationFailure
+}
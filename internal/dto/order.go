@@ -1,12 +1,68 @@
 package dto
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/entity"
+)
 
 // OrderResponse represents an order as exposed via transport layers.
 type OrderResponse struct {
-	ID        int64     `json:"id"`
-	Number    string    `json:"number"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            int64     `json:"id"`
+	Number        string    `json:"number"`
+	Status        string    `json:"status"`
+	DisplayStatus string    `json:"display_status"`
+	AgeSeconds    int64     `json:"age_seconds"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// NewOrderResponse maps an order entity onto its transport representation,
+// computing derived fields that are not persisted.
+func NewOrderResponse(order *entity.Order) OrderResponse {
+	return newOrderResponse(order, time.Now().UTC())
+}
+
+func newOrderResponse(order *entity.Order, now time.Time) OrderResponse {
+	resp := OrderResponse{
+		ID:            order.ID,
+		Number:        order.Number,
+		Status:        string(order.Status),
+		DisplayStatus: displayStatus(string(order.Status)),
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+	}
+	// Never render updated_at as 0001-01-01 for orders that were never updated.
+	if resp.UpdatedAt.IsZero() {
+		resp.UpdatedAt = resp.CreatedAt
+	}
+	if !order.CreatedAt.IsZero() && now.After(order.CreatedAt) {
+		resp.AgeSeconds = int64(now.Sub(order.CreatedAt) / time.Second)
+	}
+	return resp
+}
+
+// displayStatus turns a machine status such as "in_transit" into "In transit".
+func displayStatus(status string) string {
+	status = strings.TrimSpace(strings.ReplaceAll(status, "_", " "))
+	if status == "" {
+		return ""
+	}
+	return strings.ToUpper(status[:1]) + strings.ToLower(status[1:])
+}
+
+// Order field limits mirror the orders table columns. Struct tags cannot
+// reference constants, so the CreateOrderRequest tags repeat them.
+const (
+	MaxOrderNumberLen = 64
+	MaxOrderStatusLen = 32
+)
+
+// CreateOrderRequest is the payload accepted when creating an order. Field
+// limits mirror the orders table columns.
+type CreateOrderRequest struct {
+	// Number is optional; the server assigns ORD-<n> when it is empty.
+	Number string `json:"number" form:"number" validate:"omitempty,max=64"`
+	Status string `json:"status" form:"status" validate:"required,max=32"`
 }
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+func TestOrderResponseRendersZeroUpdatedAtAsCreatedAt(t *testing.T) {
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	resp := newOrderResponse(&entity.Order{ID: 1, Number: "ORD-1", Status: entity.OrderStatusPending, CreatedAt: created}, created.Add(time.Minute))
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var rendered map[string]any
+	if err := json.Unmarshal(body, &rendered); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if rendered["updated_at"] != "2026-03-04T05:06:07Z" {
+		t.Fatalf("updated_at = %v, want the created_at timestamp", rendered["updated_at"])
+	}
+	if rendered["age_seconds"] != float64(60) {
+		t.Fatalf("age_seconds = %v, want 60", rendered["age_seconds"])
+	}
+}
+
+func TestCreateOrderRequestTagsMatchLimits(t *testing.T) {
+	typ := reflect.TypeOf(CreateOrderRequest{})
+	for field, limit := range map[string]int{"Number": MaxOrderNumberLen, "Status": MaxOrderStatusLen} {
+		f, _ := typ.FieldByName(field)
+		if tag, want := f.Tag.Get("validate"), "max="+strconv.Itoa(limit); !strings.Contains(tag, want) {
+			t.Errorf("%s validate tag = %q, want it to contain %s", field, tag, want)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -10,9 +13,70 @@ import (
 type Order struct {
 	bun.BaseModel `bun:"table:orders"`
 
-	ID        int64     `bun:",pk,autoincrement"`
-	Number    string    `bun:"number"`
-	Status    string    `bun:"status"`
-	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:CURRENT_TIMESTAMP"`
-	UpdatedAt time.Time `bun:"updated_at,nullzero"`
+	ID        int64       `bun:",pk,autoincrement"`
+	Number    string      `bun:"number"`
+	Status    OrderStatus `bun:"status"`
+	CreatedAt time.Time   `bun:"created_at,nullzero,notnull,default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time   `bun:"updated_at,nullzero"`
+}
+
+// BackfillUpdatedAt sets a zero UpdatedAt to CreatedAt. Rows inserted outside
+// the service (raw SQL, migrations) may leave updated_at NULL; an order that was
+// never updated was last modified when it was created.
+func (o *Order) BackfillUpdatedAt() {
+	if o.UpdatedAt.IsZero() {
+		o.UpdatedAt = o.CreatedAt
+	}
+}
+
+// OrderStatus is the lifecycle state of an order.
+type OrderStatus string
+
+// Supported order statuses.
+const (
+	OrderStatusPending    OrderStatus = "pending"
+	OrderStatusProcessing OrderStatus = "processing"
+	OrderStatusShipped    OrderStatus = "shipped"
+	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusCompleted  OrderStatus = "completed"
+)
+
+var orderStatuses = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusProcessing,
+	OrderStatusShipped,
+	OrderStatusCancelled,
+	OrderStatusCompleted,
+}
+
+// ErrInvalidOrderStatus is returned by ParseOrderStatus for unknown values.
+var ErrInvalidOrderStatus = errors.New("invalid order status")
+
+// OrderStatuses returns every supported status as strings, in lifecycle order.
+func OrderStatuses() []string {
+	values := make([]string, len(orderStatuses))
+	for i, status := range orderStatuses {
+		values[i] = string(status)
+	}
+	return values
+}
+
+// Valid reports whether s is a supported status.
+func (s OrderStatus) Valid() bool {
+	for _, status := range orderStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOrderStatus normalises value (trimmed, case-insensitive) into an
+// OrderStatus, wrapping ErrInvalidOrderStatus when it is not supported.
+func ParseOrderStatus(value string) (OrderStatus, error) {
+	status := OrderStatus(strings.ToLower(strings.TrimSpace(value)))
+	if !status.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrInvalidOrderStatus, value)
+	}
+	return status, nil
 }
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Outbox event states.
+const (
+	OutboxPending = "pending"
+	OutboxSent    = "sent"
+	OutboxDead    = "dead"
+)
+
+// OutboxEvent is a message persisted alongside domain writes and published
+// later by the outbox relay. Sequence orders events within one aggregate.
+type OutboxEvent struct {
+	bun.BaseModel `bun:"table:outbox_events,alias:e"`
+
+	ID          int64             `bun:",pk,autoincrement"`
+	AggregateID string            `bun:"aggregate_id"`
+	Sequence    int64             `bun:"sequence"`
+	Topic       string            `bun:"topic"`
+	Key         []byte            `bun:"message_key"`
+	Payload     []byte            `bun:"payload"`
+	Headers     map[string]string `bun:"headers,type:jsonb"`
+	Status      string            `bun:"status"`
+	Attempts    int               `bun:"attempts"`
+	LastError   string            `bun:"last_error,nullzero"`
+	// NextAttemptAt holds a failed event back from the relay until then.
+	NextAttemptAt time.Time `bun:"next_attempt_at,nullzero"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:CURRENT_TIMESTAMP"`
+	SentAt        time.Time `bun:"sent_at,nullzero"`
+}
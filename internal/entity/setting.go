@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Setting is a runtime-editable configuration value persisted by key.
+type Setting struct {
+	bun.BaseModel `bun:"table:settings,alias:s"`
+
+	Key       string    `bun:"key,pk"`
+	Value     string    `bun:"value"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Dependency states reported by readiness checks.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// checkTimeout bounds each dependency check so one hung dependency cannot
+// stall the probe.
+const checkTimeout = 2 * time.Second
+
+// Group is the Fx value group readiness collects checks from.
+const Group = `group:"health.checks"`
+
+// HealthCheck is a named readiness probe contributed by a module. A zero
+// HealthCheck (nil Check) is ignored, so a module can opt out at runtime,
+// e.g. when its dependency is disabled.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// AsHealthCheck annotates a constructor returning HealthCheck so it joins the
+// health.checks group, e.g. fx.Provide(health.AsHealthCheck(newHealthCheck)).
+func AsHealthCheck(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(Group))
+}
+
+// Report is the outcome of a readiness check.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Ready reports whether every dependency is up.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Module provides the readiness aggregator to the Fx graph.
+var Module = fx.Provide(NewReadiness)
+
+// Params collects the registered checks via Fx.
+type Params struct {
+	fx.In
+
+	Checks []HealthCheck `group:"health.checks"`
+	Logger *zap.Logger
+}
+
+// Readiness runs every registered HealthCheck.
+type Readiness struct {
+	checks []HealthCheck
+	logger *zap.Logger
+}
+
+// NewReadiness collects the registered checks, rejecting duplicate names.
+func NewReadiness(p Params) (*Readiness, error) {
+	r := &Readiness{logger: p.Logger}
+	seen := make(map[string]struct{}, len(p.Checks))
+	for _, check := range p.Checks {
+		if check.Check == nil {
+			continue
+		}
+		if _, ok := seen[check.Name]; ok {
+			return nil, fmt.Errorf("health check %q registered twice", check.Name)
+		}
+		seen[check.Name] = struct{}{}
+		r.checks = append(r.checks, check)
+	}
+	return r, nil
+}
+
+// Check runs every registered check concurrently.
+func (r *Readiness) Check(ctx context.Context) Report {
+	report := Report{Status: StatusUp, Checks: make(map[string]string, len(r.checks))}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, check := range r.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			status := StatusUp
+			if err := check.Check(ctx); err != nil {
+				status = StatusDown
+				r.logger.Warn("readiness check failed", zap.String("dependency", check.Name), zap.Error(err))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = status
+			if status == StatusDown {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
@@ -12,8 +12,8 @@ import (
 	"github.com/Additional-Code/atlas/internal/config"
 )
 
-// Module exposes a configured Zap logger to the Fx container.
-var Module = fx.Provide(New)
+// Module exposes a configured Zap logger and payload masker to the Fx container.
+var Module = fx.Provide(New, NewPayloadMasker)
 
 // New builds a production Zap logger; callers own the cleanup via Fx lifecycle.
 func New(lc fx.Lifecycle, cfg config.Config) (*zap.Logger, error) {
@@ -48,6 +48,11 @@ func New(lc fx.Lifecycle, cfg config.Config) (*zap.Logger, error) {
 		zap.String("environment", observability.Environment),
 	)
 
+	// Config loads before the logger exists, so its dotenv problems surface here.
+	for _, err := range config.EnvFileErrors() {
+		logger.Warn("dotenv file ignored; its variables were not applied (set ATLAS_ENV_STRICT=true to fail instead)", zap.Error(err))
+	}
+
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
 			return logger.Sync()
@@ -0,0 +1,92 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+const maskedValue = "[REDACTED]"
+
+// Masker redacts configured JSON field paths from payloads before they are
+// logged. A path is dot-separated ("customer.email") and descends through
+// arrays transparently, so "items.sku" masks the sku of every item.
+type Masker struct {
+	paths [][]string
+}
+
+// NewMasker builds a Masker for paths; blank paths are ignored.
+func NewMasker(paths []string) *Masker {
+	m := &Masker{}
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		m.paths = append(m.paths, strings.Split(path, "."))
+	}
+	return m
+}
+
+// NewPayloadMasker provides the Masker configured by OBS_LOG_MASK_FIELDS.
+func NewPayloadMasker(cfg config.Config) *Masker {
+	return NewMasker(cfg.Observability.LogMaskFields)
+}
+
+// Mask returns payload with every configured path replaced by "[REDACTED]".
+// With paths configured, a payload that is not JSON is redacted entirely,
+// since its fields cannot be located.
+func (m *Masker) Mask(payload []byte) []byte {
+	if m == nil || len(m.paths) == 0 {
+		return payload
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil || decoder.More() {
+		return []byte(`"` + maskedValue + `"`)
+	}
+	for _, path := range m.paths {
+		doc = maskPath(doc, path)
+	}
+	masked, err := json.Marshal(doc)
+	if err != nil {
+		return []byte(`"` + maskedValue + `"`)
+	}
+	return masked
+}
+
+// Field returns a log field holding the masked payload, embedded as JSON when
+// it is JSON.
+func (m *Masker) Field(key string, payload []byte) zap.Field {
+	masked := m.Mask(payload)
+	if !json.Valid(masked) {
+		return zap.ByteString(key, masked)
+	}
+	return zap.Any(key, json.RawMessage(masked))
+}
+
+func maskPath(node any, path []string) any {
+	switch value := node.(type) {
+	case map[string]any:
+		child, ok := value[path[0]]
+		if !ok {
+			return node
+		}
+		if len(path) == 1 {
+			value[path[0]] = maskedValue
+		} else {
+			value[path[0]] = maskPath(child, path[1:])
+		}
+	case []any:
+		for i := range value {
+			value[i] = maskPath(value[i], path)
+		}
+	}
+	return node
+}
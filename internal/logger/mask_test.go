@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestMaskerRedactsConfiguredFieldsOnly(t *testing.T) {
+	masker := NewMasker([]string{"customer.email", "items.sku", " ", "missing.path"})
+	payload := []byte(`{"id":12345678901234567890,"customer":{"email":"ada@example.com","name":"Ada"},"items":[{"sku":"A1","qty":2},{"sku":"B2","qty":1}]}`)
+
+	masked := masker.Mask(payload)
+	if !bytes.Contains(masked, []byte(`"id":12345678901234567890`)) {
+		t.Errorf("masked = %s, want the id preserved exactly", masked)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(masked, &got); err != nil {
+		t.Fatalf("masked payload is not JSON: %v", err)
+	}
+	customer := got["customer"].(map[string]any)
+	if customer["email"] != maskedValue || customer["name"] != "Ada" {
+		t.Errorf("customer = %v, want email masked and name kept", customer)
+	}
+	for _, item := range got["items"].([]any) {
+		item := item.(map[string]any)
+		if item["sku"] != maskedValue || item["qty"] == nil {
+			t.Errorf("item = %v, want sku masked and qty kept", item)
+		}
+	}
+}
+
+func TestMaskerRedactsNonJSONPayloadEntirely(t *testing.T) {
+	if got := string(NewMasker([]string{"email"}).Mask([]byte("email=ada@example.com"))); got != `"[REDACTED]"` {
+		t.Fatalf("Mask = %s, want the whole payload redacted", got)
+	}
+	if got := string(NewMasker(nil).Mask([]byte("plain"))); got != "plain" {
+		t.Fatalf("Mask without paths = %s, want the payload unchanged", got)
+	}
+}
@@ -0,0 +1,6 @@
+package maintenance
+
+import "go.uber.org/fx"
+
+// Module provides the maintenance query runner to Fx.
+var Module = fx.Provide(NewRunner)
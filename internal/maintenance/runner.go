@@ -0,0 +1,102 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/database"
+)
+
+var maintenanceTracer = otel.Tracer("github.com/Additional-Code/atlas/maintenance")
+
+var (
+	// ErrUnknownQuery is returned when a query name is not whitelisted.
+	ErrUnknownQuery = errors.New("unknown maintenance query")
+	// ErrMissingParam is returned when a required query parameter is absent.
+	ErrMissingParam = errors.New("missing query parameter")
+)
+
+// Query is a named, parameterised read that support engineers may run.
+type Query struct {
+	Name        string
+	Description string
+	SQL         string
+	// Params lists the named parameters bound, in order, to the SQL placeholders.
+	Params []string
+}
+
+// queries is the whitelist of runnable statements. Only reads belong here.
+var queries = map[string]Query{
+	"orders_by_status": {
+		Name:        "orders_by_status",
+		Description: "Latest 100 orders with the given status",
+		SQL:         "SELECT id, number, status, created_at, updated_at FROM orders WHERE status = ? ORDER BY id DESC LIMIT 100",
+		Params:      []string{"status"},
+	},
+	"order_by_number": {
+		Name:        "order_by_number",
+		Description: "Order matching the given number",
+		SQL:         "SELECT id, number, status, created_at, updated_at FROM orders WHERE number = ?",
+		Params:      []string{"number"},
+	},
+}
+
+// Queries lists the whitelisted queries sorted by name.
+func Queries() []Query {
+	list := make([]Query, 0, len(queries))
+	for _, q := range queries {
+		list = append(list, q)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Runner executes whitelisted queries against the read replica.
+type Runner struct {
+	conns  *database.Connections
+	logger *zap.Logger
+}
+
+// NewRunner constructs a Runner backed by the reader connection.
+func NewRunner(conns *database.Connections, logger *zap.Logger) *Runner {
+	return &Runner{conns: conns, logger: logger}
+}
+
+// Run executes the named query with params and returns each row as a column map.
+func (r *Runner) Run(ctx context.Context, name string, params map[string]string) ([]map[string]any, error) {
+	query, ok := queries[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownQuery, name)
+	}
+
+	args := make([]any, 0, len(query.Params))
+	for _, param := range query.Params {
+		value, ok := params[param]
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %s", ErrMissingParam, param)
+		}
+		args = append(args, value)
+	}
+
+	ctx, span := maintenanceTracer.Start(ctx, "MaintenanceRunner.Run", trace.WithAttributes(attribute.String("query.name", name)))
+	defer span.End()
+
+	rows := make([]map[string]any, 0)
+	if err := r.conns.ReadDB(ctx).NewRaw(query.SQL, args...).Scan(ctx, &rows); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "query failed")
+		return nil, err
+	}
+
+	r.logger.Info("maintenance query executed", zap.String("query", name), zap.Int("rows", len(rows)))
+
+	return rows, nil
+}
@@ -0,0 +1,58 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+func newTestRunner(t *testing.T) *Runner {
+	t.Helper()
+	db := dbtest.New(t)
+	dbtest.CreateTables(t, db, (*entity.Order)(nil))
+	now := time.Now().UTC()
+	orders := []entity.Order{
+		{Number: "ORDER-1", Status: entity.OrderStatusPending, CreatedAt: now, UpdatedAt: now},
+		{Number: "ORDER-2", Status: entity.OrderStatusProcessing, CreatedAt: now, UpdatedAt: now},
+	}
+	if _, err := db.NewInsert().Model(&orders).Exec(t.Context()); err != nil {
+		t.Fatalf("insert orders: %v", err)
+	}
+	return NewRunner(dbtest.Connections(db), zap.NewNop())
+}
+
+func TestRunWhitelistedQuery(t *testing.T) {
+	runner := newTestRunner(t)
+
+	rows, err := runner.Run(context.Background(), "order_by_number", map[string]string{"number": "ORDER-2"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(rows) != 1 || rows[0]["number"] != "ORDER-2" {
+		t.Fatalf("rows = %v, want ORDER-2 only", rows)
+	}
+}
+
+func TestRunRejectsUnknownQuery(t *testing.T) {
+	runner := newTestRunner(t)
+
+	_, err := runner.Run(context.Background(), "drop_orders", nil)
+	if !errors.Is(err, ErrUnknownQuery) {
+		t.Fatalf("Run error = %v, want ErrUnknownQuery", err)
+	}
+}
+
+func TestRunRequiresParams(t *testing.T) {
+	runner := newTestRunner(t)
+
+	_, err := runner.Run(context.Background(), "orders_by_status", map[string]string{})
+	if !errors.Is(err, ErrMissingParam) {
+		t.Fatalf("Run error = %v, want ErrMissingParam", err)
+	}
+}
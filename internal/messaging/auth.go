@@ -0,0 +1,66 @@
+package messaging
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// newSASLMechanism maps the configured mechanism onto kafka-go's; nil means
+// SASL is disabled.
+func newSASLMechanism(cfg config.KafkaSASL) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case "":
+		return nil, nil
+	case config.KafkaSASLPlain:
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case config.KafkaSASLScramSHA256:
+		return scramMechanism(scram.SHA256, cfg)
+	case config.KafkaSASLScramSHA512:
+		return scramMechanism(scram.SHA512, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported kafka SASL mechanism %q", cfg.Mechanism)
+	}
+}
+
+func scramMechanism(algo scram.Algorithm, cfg config.KafkaSASL) (sasl.Mechanism, error) {
+	mechanism, err := scram.Mechanism(algo, cfg.Username, cfg.Password)
+	if err != nil {
+		return nil, fmt.Errorf("kafka SASL %s: %w", cfg.Mechanism, err)
+	}
+	return mechanism, nil
+}
+
+// newKafkaTLS returns nil when TLS is disabled.
+func newKafkaTLS(cfg config.KafkaTLS) *tls.Config {
+	if !cfg.Enabled {
+		return nil
+	}
+	return &tls.Config{MinVersion: tls.VersionTLS12}
+}
+
+// newKafkaDialer builds the reader's dialer with the configured authentication.
+func newKafkaDialer(cfg config.Kafka, mechanism sasl.Mechanism) *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       cfg.ConnectTimeout,
+		ClientID:      cfg.ClientID,
+		SASLMechanism: mechanism,
+		TLS:           newKafkaTLS(cfg.TLS),
+	}
+}
+
+// newKafkaTransport builds the writer's transport with the same authentication.
+func newKafkaTransport(cfg config.Kafka, mechanism sasl.Mechanism) *kafka.Transport {
+	return &kafka.Transport{
+		DialTimeout: cfg.ConnectTimeout,
+		ClientID:    cfg.ClientID,
+		SASL:        mechanism,
+		TLS:         newKafkaTLS(cfg.TLS),
+	}
+}
@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func TestKafkaConnectionsCarrySASLMechanism(t *testing.T) {
+	tests := []struct {
+		mechanism string
+		want      string
+	}{
+		{mechanism: config.KafkaSASLPlain, want: "PLAIN"},
+		{mechanism: config.KafkaSASLScramSHA256, want: "SCRAM-SHA-256"},
+		{mechanism: config.KafkaSASLScramSHA512, want: "SCRAM-SHA-512"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mechanism, func(t *testing.T) {
+			cfg := config.Kafka{ClientID: "atlas", ConnectTimeout: 5 * time.Second, TLS: config.KafkaTLS{Enabled: true}}
+			cfg.SASL = config.KafkaSASL{Mechanism: tt.mechanism, Username: "user", Password: "secret"}
+			mechanism, err := newSASLMechanism(cfg.SASL)
+			if err != nil {
+				t.Fatalf("newSASLMechanism: %v", err)
+			}
+
+			dialer := newKafkaDialer(cfg, mechanism)
+			if dialer.SASLMechanism == nil || dialer.SASLMechanism.Name() != tt.want {
+				t.Fatalf("dialer mechanism = %v, want %s", dialer.SASLMechanism, tt.want)
+			}
+			if dialer.TLS == nil || dialer.ClientID != "atlas" || dialer.Timeout != 5*time.Second {
+				t.Fatalf("dialer = %+v, want TLS, client ID, and timeout set", dialer)
+			}
+
+			transport := newKafkaTransport(cfg, mechanism)
+			if transport.SASL == nil || transport.SASL.Name() != tt.want {
+				t.Fatalf("transport mechanism = %v, want %s", transport.SASL, tt.want)
+			}
+			if transport.TLS == nil || transport.ClientID != "atlas" || transport.DialTimeout != 5*time.Second {
+				t.Fatalf("transport = %+v, want TLS, client ID, and timeout set", transport)
+			}
+		})
+	}
+}
+
+func TestNewSASLMechanismDisabledAndUnknown(t *testing.T) {
+	mechanism, err := newSASLMechanism(config.KafkaSASL{})
+	if err != nil || mechanism != nil {
+		t.Fatalf("no mechanism = %v, %v; want nil, nil", mechanism, err)
+	}
+	if dialer := newKafkaDialer(config.Kafka{}, nil); dialer.SASLMechanism != nil || dialer.TLS != nil {
+		t.Fatalf("dialer = %+v, want no SASL or TLS", dialer)
+	}
+	if _, err := newSASLMechanism(config.KafkaSASL{Mechanism: "gssapi"}); err == nil {
+		t.Fatal("unknown mechanism accepted")
+	}
+}
@@ -0,0 +1,118 @@
+package messaging
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// HeaderPayloadEncoding lists the transforms applied to a message value, in
+// order, e.g. "gzip+aes-gcm". Consumers reverse them before handlers run.
+const HeaderPayloadEncoding = "atlas-payload-encoding"
+
+const (
+	encodingGzip   = "gzip"
+	encodingAESGCM = "aes-gcm"
+)
+
+// payloadCodec compresses and/or encrypts message values at the client
+// boundary. The zero value passes payloads through unchanged.
+type payloadCodec struct {
+	compress bool
+	aead     cipher.AEAD
+}
+
+func newPayloadCodec(cfg config.KafkaPayload) (payloadCodec, error) {
+	codec := payloadCodec{compress: cfg.Compression == config.KafkaCompressionGzip}
+	if cfg.EncryptionKey == "" {
+		return codec, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(cfg.EncryptionKey)
+	if err != nil {
+		return payloadCodec{}, fmt.Errorf("decode payload encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return payloadCodec{}, fmt.Errorf("payload encryption key: %w", err)
+	}
+	if codec.aead, err = cipher.NewGCM(block); err != nil {
+		return payloadCodec{}, err
+	}
+	return codec, nil
+}
+
+// encode transforms value and returns the header describing what was applied,
+// or "" when the codec is a pass-through.
+func (c payloadCodec) encode(value []byte) ([]byte, string, error) {
+	var applied []string
+	if c.compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(value); err != nil {
+			return nil, "", err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, "", err
+		}
+		value = buf.Bytes()
+		applied = append(applied, encodingGzip)
+	}
+	if c.aead != nil {
+		nonce := make([]byte, c.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, "", err
+		}
+		// The nonce is prepended so the consumer needs nothing but the key.
+		value = c.aead.Seal(nonce, nonce, value, nil)
+		applied = append(applied, encodingAESGCM)
+	}
+	return value, strings.Join(applied, "+"), nil
+}
+
+// decode reverses the transforms named by encoding.
+func (c payloadCodec) decode(value []byte, encoding string) ([]byte, error) {
+	if encoding == "" {
+		return value, nil
+	}
+	steps := strings.Split(encoding, "+")
+	for i := len(steps) - 1; i >= 0; i-- {
+		switch steps[i] {
+		case encodingAESGCM:
+			if c.aead == nil {
+				return nil, errors.New("encrypted payload but no KAFKA_PAYLOAD_ENCRYPTION_KEY configured")
+			}
+			size := c.aead.NonceSize()
+			if len(value) < size {
+				return nil, errors.New("encrypted payload shorter than nonce")
+			}
+			plain, err := c.aead.Open(nil, value[:size], value[size:], nil)
+			if err != nil {
+				return nil, fmt.Errorf("decrypt payload: %w", err)
+			}
+			value = plain
+		case encodingGzip:
+			zr, err := gzip.NewReader(bytes.NewReader(value))
+			if err != nil {
+				return nil, fmt.Errorf("decompress payload: %w", err)
+			}
+			plain, err := io.ReadAll(zr)
+			if err != nil {
+				return nil, fmt.Errorf("decompress payload: %w", err)
+			}
+			value = plain
+		default:
+			return nil, fmt.Errorf("unknown payload encoding %q", steps[i])
+		}
+	}
+	return value, nil
+}
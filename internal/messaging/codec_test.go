@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func newTestCodec(t *testing.T, key byte) payloadCodec {
+	t.Helper()
+	codec, err := newPayloadCodec(config.KafkaPayload{
+		Compression:   config.KafkaCompressionGzip,
+		EncryptionKey: base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{key}, 32)),
+	})
+	if err != nil {
+		t.Fatalf("newPayloadCodec: %v", err)
+	}
+	return codec
+}
+
+func TestPayloadCodecRoundTrip(t *testing.T) {
+	codec := newTestCodec(t, 1)
+	plain := []byte(`{"id":1,"number":"ORD-1"}`)
+
+	encoded, encoding, err := codec.encode(plain)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if encoding != "gzip+aes-gcm" {
+		t.Fatalf("encoding = %q, want gzip+aes-gcm", encoding)
+	}
+	if bytes.Contains(encoded, []byte("ORD-1")) {
+		t.Fatal("encoded payload contains plaintext")
+	}
+
+	decoded, err := codec.decode(encoded, encoding)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !bytes.Equal(decoded, plain) {
+		t.Fatalf("decoded = %q, want %q", decoded, plain)
+	}
+	if _, err := newTestCodec(t, 2).decode(encoded, encoding); err == nil {
+		t.Fatal("decode with a different key succeeded")
+	}
+}
+
+func TestEncryptedMessageReachesHandlerAsPlaintext(t *testing.T) {
+	writer := &fakeWriter{}
+	client := newTestClient(writer, false)
+	client.codec = newTestCodec(t, 1)
+
+	plain := []byte(`{"id":1}`)
+	if err := client.PublishMessage(context.Background(), Message{Value: plain}); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	written := writer.messages()[0]
+	if bytes.Equal(written.Value, plain) {
+		t.Fatal("value was written unencrypted")
+	}
+
+	var received []byte
+	client.process(context.Background(), &fakeCommitter{}, written, func(_ context.Context, msg Message) error {
+		received = msg.Value
+		return nil
+	})
+	if !bytes.Equal(received, plain) {
+		t.Fatalf("handler received %q, want %q", received, plain)
+	}
+}
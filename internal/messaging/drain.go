@@ -0,0 +1,42 @@
+package messaging
+
+import "context"
+
+type drainKey struct{}
+
+// WithDrain returns a context under which Consume stops fetching once drain is
+// closed, returning nil after the message already in hand is handled and
+// committed. Cancelling the context itself still aborts immediately.
+func WithDrain(ctx context.Context, drain <-chan struct{}) context.Context {
+	return context.WithValue(ctx, drainKey{}, drain)
+}
+
+// drainSignal returns the drain channel on ctx; nil (never ready) when unset.
+func drainSignal(ctx context.Context) <-chan struct{} {
+	drain, _ := ctx.Value(drainKey{}).(<-chan struct{})
+	return drain
+}
+
+func draining(ctx context.Context) bool {
+	select {
+	case <-drainSignal(ctx):
+		return true
+	default:
+		return false
+	}
+}
+
+// fetchContext derives the context used for fetching, cancelled on drain.
+func fetchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	if drain := drainSignal(ctx); drain != nil {
+		go func() {
+			select {
+			case <-drain:
+				cancel()
+			case <-fetchCtx.Done():
+			}
+		}()
+	}
+	return fetchCtx, cancel
+}
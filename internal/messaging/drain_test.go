@@ -0,0 +1,67 @@
+package messaging
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// fakeReader hands out queued messages and then blocks until ctx ends.
+type fakeReader struct {
+	fakeCommitter
+	queue   chan kafka.Message
+	fetches atomic.Int64
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	select {
+	case msg := <-r.queue:
+		r.fetches.Add(1)
+		return msg, nil
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	}
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+func TestConsumeDrainFinishesInFlightAndStopsFetching(t *testing.T) {
+	reader := &fakeReader{queue: make(chan kafka.Message, 2)}
+	reader.queue <- kafka.Message{Topic: "orders", Offset: 1}
+	reader.queue <- kafka.Message{Topic: "orders", Offset: 2}
+	client := newTestClient(&fakeWriter{}, false)
+	client.readerOnce.Do(func() {})
+	client.reader = reader
+
+	drain := make(chan struct{})
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := func(context.Context, Message) error {
+		close(started)
+		<-release
+		return nil
+	}
+	done := make(chan error, 1)
+	go func() { done <- client.Consume(WithDrain(context.Background(), drain), handler) }()
+
+	<-started
+	close(drain)
+	close(release)
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Consume after drain = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Consume did not return after drain")
+	}
+	if got := reader.fetches.Load(); got != 1 {
+		t.Fatalf("fetches = %d, want 1: nothing fetched after the drain", got)
+	}
+	if reader.count() != 1 || reader.committed[0].Offset != 1 {
+		t.Fatalf("commits = %+v, want the in-flight offset 1", reader.committed)
+	}
+}
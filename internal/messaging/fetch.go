@@ -0,0 +1,69 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// Delivery is a fetched message that has not been processed yet.
+type Delivery interface {
+	// Message returns routing metadata (topic, key, partition, offset); the
+	// decoded value and headers reach the handler in Process.
+	Message() Message
+	// Process runs handler with the client's retry policy, then commits the
+	// message or dead-letters it, exactly as Consume would.
+	Process(ctx context.Context, handler Handler)
+}
+
+// TopicFetcher is implemented by clients that can read each topic on its own
+// reader, letting the caller decide which topic to service next.
+type TopicFetcher interface {
+	// Fetch blocks until the next message on topic is available.
+	Fetch(ctx context.Context, topic string) (Delivery, error)
+}
+
+type kafkaDelivery struct {
+	client *kafkaClient
+	reader *kafka.Reader
+	msg    kafka.Message
+}
+
+func (d kafkaDelivery) Message() Message {
+	return Message{Topic: d.msg.Topic, Key: d.msg.Key, Partition: d.msg.Partition, Offset: d.msg.Offset, Time: d.msg.Time}
+}
+
+func (d kafkaDelivery) Process(ctx context.Context, handler Handler) {
+	d.client.process(ctx, d.reader, d.msg, handler)
+}
+
+// Fetch reads the next message on topic from that topic's reader, creating the
+// reader on first use. All readers share the consumer group.
+func (k *kafkaClient) Fetch(ctx context.Context, topic string) (Delivery, error) {
+	reader := k.topicReader(topic)
+	msg, err := reader.FetchMessage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return kafkaDelivery{client: k, reader: reader, msg: msg}, nil
+}
+
+func (k *kafkaClient) topicReader(topic string) *kafka.Reader {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if reader, ok := k.topicReaders[topic]; ok {
+		return reader
+	}
+	if k.topicReaders == nil {
+		k.topicReaders = make(map[string]*kafka.Reader)
+	}
+	readerConfig := k.readerConfig
+	readerConfig.Topic = topic
+	reader := kafka.NewReader(readerConfig)
+	k.topicReaders[topic] = reader
+	k.logger.Info("kafka topic reader started", zap.String("topic", topic))
+
+	return reader
+}
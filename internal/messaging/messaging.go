@@ -4,23 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/health"
+	"github.com/Additional-Code/atlas/internal/observability"
 )
 
 // Message represents a message consumed from the bus.
 type Message struct {
-	Topic   string
-	Key     []byte
-	Value   []byte
-	Headers map[string]string
-	Offset  int64
-	Time    time.Time
+	Topic     string
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Partition int
+	Offset    int64
+	Time      time.Time
 }
 
 // Handler processes an inbound message.
@@ -29,42 +36,179 @@ type Handler func(context.Context, Message) error
 // Client is the pluggable messaging abstraction.
 type Client interface {
 	Publish(ctx context.Context, key []byte, value []byte) error
+	// PublishTo writes to topic instead of the default one.
+	PublishTo(ctx context.Context, topic string, key []byte, value []byte) error
+	// PublishMessage writes msg to msg.Topic (the default topic when empty)
+	// carrying msg.Headers. Partition, Offset and Time are ignored.
+	PublishMessage(ctx context.Context, msg Message) error
+	// Subscribe adds topics to those Consume reads, alongside the configured
+	// ones. It must be called before the first Consume.
+	Subscribe(topics ...string)
 	Consume(ctx context.Context, handler Handler) error
 	Topic() string
 }
 
-// Module wires the messaging client.
-var Module = fx.Provide(NewClient)
+// Module wires the messaging client and, for brokers, its readiness check.
+var Module = fx.Provide(NewClient, health.AsHealthCheck(newHealthCheck))
+
+// newHealthCheck checks the brokers; the noop client opts out.
+func newHealthCheck(client Client) health.HealthCheck {
+	checker, ok := client.(interface{ HealthCheck(context.Context) error })
+	if !ok {
+		return health.HealthCheck{}
+	}
+	return health.HealthCheck{Name: "messaging", Check: checker.HealthCheck}
+}
 
 // noopClient is used when messaging is disabled.
 type noopClient struct {
 	topic string
 }
 
-func (n noopClient) Publish(context.Context, []byte, []byte) error { return nil }
+func (n noopClient) Publish(context.Context, []byte, []byte) error           { return nil }
+func (n noopClient) PublishTo(context.Context, string, []byte, []byte) error { return nil }
+func (n noopClient) PublishMessage(context.Context, Message) error           { return nil }
 func (n noopClient) Consume(ctx context.Context, handler Handler) error {
-	<-ctx.Done()
-	return ctx.Err()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-drainSignal(ctx):
+		return nil
+	}
 }
+func (n noopClient) Subscribe(...string) {}
+
 func (n noopClient) Topic() string { return n.topic }
 
+// messageWriter is the part of *kafka.Writer the client publishes through.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// committer is the part of *kafka.Reader the client commits offsets through.
+type committer interface {
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// messageReader is the part of *kafka.Reader Consume fetches and commits through.
+type messageReader interface {
+	committer
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	Close() error
+}
+
 // kafkaClient implements the Client via kafka-go.
 type kafkaClient struct {
-	writer *kafka.Writer
-	reader *kafka.Reader
-	topic  string
-	logger *zap.Logger
+	writer messageWriter
+	// reader is built on the first Consume so it covers every subscribed topic.
+	reader       messageReader
+	readerConfig kafka.ReaderConfig
+	readerOnce   sync.Once
+	// topicReaders serve Fetch, one reader per topic.
+	topicReaders map[string]*kafka.Reader
+	mu           sync.Mutex
+	topics       []string
+	topic        string
+	codec        payloadCodec
+	retry        config.KafkaRetry
+	dlq          config.KafkaDLQ
+	propagator   propagation.TextMapPropagator
+	logger       *zap.Logger
 }
 
+// Headers added to dead-lettered messages.
+const (
+	HeaderDLQOriginalTopic     = "dlq-original-topic"
+	HeaderDLQOriginalPartition = "dlq-original-partition"
+	HeaderDLQOriginalOffset    = "dlq-original-offset"
+	HeaderDLQError             = "dlq-error"
+	HeaderDLQAttempts          = "dlq-attempts"
+)
+
 func (k *kafkaClient) Publish(ctx context.Context, key []byte, value []byte) error {
-	msg := kafka.Message{Topic: k.topic, Key: key, Value: value}
-	return k.writer.WriteMessages(ctx, msg)
+	return k.PublishMessage(ctx, Message{Key: key, Value: value})
+}
+
+func (k *kafkaClient) PublishTo(ctx context.Context, topic string, key []byte, value []byte) error {
+	return k.PublishMessage(ctx, Message{Topic: topic, Key: key, Value: value})
+}
+
+func (k *kafkaClient) PublishMessage(ctx context.Context, msg Message) error {
+	topic := msg.Topic
+	if topic == "" {
+		topic = k.topic
+	}
+	value, encoding, err := k.codec.encode(msg.Value)
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	// Trace headers already on the message (e.g. captured in the outbox) win
+	// over the publishing context's.
+	carrier := propagation.MapCarrier{}
+	k.propagator.Inject(ctx, carrier)
+	for key, value := range msg.Headers {
+		carrier[key] = value
+	}
+	delete(carrier, HeaderPayloadEncoding)
+	if encoding != "" {
+		carrier[HeaderPayloadEncoding] = encoding
+	}
+
+	out := kafka.Message{Topic: topic, Key: msg.Key, Value: value}
+	for key, value := range carrier {
+		out.Headers = append(out.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	return k.writer.WriteMessages(ctx, out)
+}
+
+func (k *kafkaClient) Subscribe(topics ...string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.reader != nil {
+		k.logger.Warn("kafka subscribe after consuming started; ignored", zap.Strings("topics", topics))
+
+		return
+	}
+	k.topics = config.MergeTopics(k.topic, append(k.topics, topics...)...)
+}
+
+// startReader creates the shared reader for the subscribed topics. A single
+// topic uses Topic; several use GroupTopics so one consumer group covers all.
+func (k *kafkaClient) startReader() {
+	k.readerOnce.Do(func() {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+
+		readerConfig := k.readerConfig
+		if len(k.topics) == 1 {
+			readerConfig.Topic = k.topics[0]
+		} else {
+			readerConfig.GroupTopics = k.topics
+		}
+		k.reader = kafka.NewReader(readerConfig)
+		k.logger.Info("kafka consumer subscribed", zap.Strings("topics", k.topics))
+	})
 }
 
 func (k *kafkaClient) Consume(ctx context.Context, handler Handler) error {
+	k.startReader()
+
+	// Draining cancels only fetching; the message in hand is still handled and
+	// committed under ctx.
+	fetchCtx, stopFetch := fetchContext(ctx)
+	defer stopFetch()
+
 	for {
-		msg, err := k.reader.FetchMessage(ctx)
+		if draining(ctx) {
+			return nil
+		}
+		msg, err := k.reader.FetchMessage(fetchCtx)
 		if err != nil {
+			if draining(ctx) {
+				return nil
+			}
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 				return err
 			}
@@ -74,42 +218,229 @@ func (k *kafkaClient) Consume(ctx context.Context, handler Handler) error {
 			continue
 		}
 
-		wrapped := Message{
-			Topic:  msg.Topic,
-			Key:    append([]byte(nil), msg.Key...),
-			Value:  append([]byte(nil), msg.Value...),
-			Offset: msg.Offset,
-			Time:   msg.Time,
-			Headers: func() map[string]string {
-				if len(msg.Headers) == 0 {
-					return nil
-				}
-				m := make(map[string]string, len(msg.Headers))
-				for _, h := range msg.Headers {
-					m[h.Key] = string(h.Value)
-				}
-				return m
-			}(),
+		k.process(ctx, k.reader, msg, handler)
+	}
+}
+
+// process decodes msg, runs handler with retries, and then commits it on
+// reader or dead-letters it. It returns only once msg is committed or ctx is
+// done, so a failing message is never skipped.
+func (k *kafkaClient) process(ctx context.Context, reader committer, msg kafka.Message, handler Handler) {
+	wrapped := fromKafka(msg)
+
+	// Undo publish-side compression/encryption so handlers see plaintext.
+	// A payload that cannot be decoded never will be, so it skips retries.
+	if encoding := wrapped.Headers[HeaderPayloadEncoding]; encoding != "" {
+		value, err := k.codec.decode(wrapped.Value, encoding)
+		if err != nil {
+			k.logger.Error("message payload decode failed", zap.Error(err), zap.Int64("offset", msg.Offset))
+
+			if !k.dlq.Enabled {
+				k.hold(ctx, msg)
+				return
+			}
+			k.deadLetter(ctx, reader, msg, err, 0)
+			return
 		}
+		wrapped.Value = value
+		delete(wrapped.Headers, HeaderPayloadEncoding)
+	}
 
-		if err := handler(ctx, wrapped); err != nil {
-			k.logger.Error("message handler failed", zap.Error(err), zap.Int64("offset", msg.Offset))
+	attempts, err := k.handle(ctx, handler, wrapped)
+	if err != nil {
+		k.logger.Error("message handler failed",
+			zap.Error(err),
+			zap.Int64("offset", msg.Offset),
+			zap.Int("attempts", attempts),
+		)
+		if ctx.Err() != nil {
+			return
+		}
+		if !k.dlq.Enabled {
+			k.retryUntilHandled(ctx, reader, msg, handler, wrapped)
+			return
+		}
+		k.deadLetter(ctx, reader, msg, err, attempts)
+		return
+	}
 
-			// Handler signals failure; skip commit to allow retry.
-			continue
+	k.commit(ctx, reader, msg)
+}
+
+// fromKafka copies msg into a Message with its value still as stored.
+func fromKafka(msg kafka.Message) Message {
+	wrapped := Message{
+		Topic:     msg.Topic,
+		Key:       append([]byte(nil), msg.Key...),
+		Value:     append([]byte(nil), msg.Value...),
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Time:      msg.Time,
+	}
+	if len(msg.Headers) > 0 {
+		wrapped.Headers = make(map[string]string, len(msg.Headers))
+		for _, h := range msg.Headers {
+			wrapped.Headers[h.Key] = string(h.Value)
+		}
+	}
+	return wrapped
+}
+
+// retryUntilHandled keeps retrying a message that exhausted its retries with
+// no DLQ to move it to, blocking the partition rather than skipping it. Each
+// round waits KAFKA_RETRY_BACKOFF_MAX and then runs the usual retries.
+func (k *kafkaClient) retryUntilHandled(ctx context.Context, reader committer, msg kafka.Message, handler Handler, wrapped Message) {
+	wait := blockingBackoff(k.retry.BackoffMax)
+	for {
+		k.logger.Error("message handler failed with the DLQ disabled; holding the partition and retrying",
+			zap.String("topic", msg.Topic),
+			zap.Int("partition", msg.Partition),
+			zap.Int64("offset", msg.Offset),
+			zap.Duration("backoff", wait),
+		)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+		_, err := k.handle(ctx, handler, wrapped)
+		if err == nil {
+			k.commit(ctx, reader, msg)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// hold blocks the partition on a message that can never be handled and has
+// no DLQ to go to. It stays uncommitted and is redelivered after a restart,
+// e.g. once KAFKA_DLQ_ENABLED is set.
+func (k *kafkaClient) hold(ctx context.Context, msg kafka.Message) {
+	k.logger.Error("undecodable message with the DLQ disabled; holding the partition until shutdown",
+		zap.String("topic", msg.Topic),
+		zap.Int("partition", msg.Partition),
+		zap.Int64("offset", msg.Offset),
+	)
+	<-ctx.Done()
+}
+
+// handle runs handler, retrying in place up to the configured max retries with
+// jittered exponential backoff. kafka-go does not redeliver uncommitted
+// messages to the same reader, so retries must happen here rather than by
+// skipping the commit. It returns the number of attempts made.
+func (k *kafkaClient) handle(ctx context.Context, handler Handler, msg Message) (int, error) {
+	delay := k.retry.BackoffBase
+	for attempt := 1; ; attempt++ {
+		err := handler(ctx, msg)
+		if err == nil || attempt > k.retry.MaxRetries || ctx.Err() != nil {
+			return attempt, err
 		}
 
-		if err := k.reader.CommitMessages(ctx, msg); err != nil {
-			k.logger.Warn("commit failed", zap.Error(err))
+		// Jitter spreads the retries of messages that failed together, e.g. on
+		// a downstream outage, without exceeding the backoff.
+		wait := delay
+		if half := delay / 2; half > 0 {
+			wait = half + time.Duration(rand.Int64N(int64(half)))
+		}
+		k.logger.Warn("message handler failed; retrying",
+			zap.Error(err),
+			zap.Int64("offset", msg.Offset),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", wait),
+		)
+
+		// Shutdown interrupts the wait; the message stays uncommitted.
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		}
+		if delay *= 2; delay > k.retry.BackoffMax {
+			delay = k.retry.BackoffMax
+		}
+	}
+}
 
+// deadLetter publishes the raw message to the DLQ topic and commits it so the
+// partition can advance. A failed DLQ write is retried with backoff until it
+// succeeds; on shutdown the message is left uncommitted for redelivery.
+func (k *kafkaClient) deadLetter(ctx context.Context, reader committer, msg kafka.Message, cause error, attempts int) {
+
+	headers := append([]kafka.Header(nil), msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderDLQOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+	)
+	// The value is forwarded as received (still encoded) so it can be replayed verbatim.
+	dead := kafka.Message{Topic: k.dlq.Topic, Key: msg.Key, Value: msg.Value, Headers: headers}
+	delay := blockingBackoff(k.retry.BackoffBase)
+	for {
+		err := k.writer.WriteMessages(ctx, dead)
+		if err == nil {
+			break
+		}
+		k.logger.Error("dead-letter publish failed; retrying",
+			zap.Error(err),
+			zap.Int64("offset", msg.Offset),
+			zap.Duration("backoff", delay),
+		)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
 		}
+		delay = blockingBackoff(min(delay*2, k.retry.BackoffMax))
+	}
+
+	k.logger.Warn("message dead-lettered",
+		zap.String("dlq_topic", k.dlq.Topic),
+		zap.Int64("offset", msg.Offset),
+		zap.Int("attempts", attempts),
+	)
+	k.commit(ctx, reader, msg)
+}
+
+// blockingBackoff keeps loops that retry indefinitely from spinning when the
+// configured backoff is zero.
+func blockingBackoff(d time.Duration) time.Duration {
+	if d <= 0 {
+		return time.Second
+	}
+	return d
+}
+
+func (k *kafkaClient) commit(ctx context.Context, reader committer, msg kafka.Message) {
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		k.logger.Warn("commit failed", zap.Error(err))
+
 	}
 }
 
 func (k *kafkaClient) Topic() string { return k.topic }
 
+// HealthCheck succeeds once any configured broker accepts a connection.
+func (k *kafkaClient) HealthCheck(ctx context.Context) error {
+	errs := make([]error, 0, len(k.readerConfig.Brokers))
+	for _, broker := range k.readerConfig.Brokers {
+		conn, err := k.readerConfig.Dialer.DialContext(ctx, "tcp", broker)
+		if err == nil {
+			return conn.Close()
+		}
+		errs = append(errs, fmt.Errorf("broker %s: %w", broker, err))
+	}
+	if len(errs) == 0 {
+		return errors.New("no kafka brokers configured")
+	}
+	return errors.Join(errs...)
+}
+
 // NewClient builds a messaging client based on configuration.
-func NewClient(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Client, error) {
+func NewClient(lc fx.Lifecycle, cfg config.Config, obs *observability.Manager, logger *zap.Logger) (Client, error) {
 	if !cfg.Messaging.Enabled || cfg.Messaging.Driver == "noop" {
 		logger.Info("messaging disabled; using noop client")
 
@@ -118,18 +449,24 @@ func NewClient(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Client,
 
 	switch cfg.Messaging.Driver {
 	case "kafka":
-		return newKafkaClient(lc, cfg, logger)
+		return newKafkaClient(lc, cfg, obs, logger)
 	default:
-		return nil, fmt.Errorf("unsupported messaging driver: %s", cfg.Messaging.Driver)
+		return nil, config.NewUnsupportedDriverError(config.ErrUnsupportedMessagingDriver, cfg.Messaging.Driver)
 	}
 }
 
-func newKafkaClient(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Client, error) {
+func newKafkaClient(lc fx.Lifecycle, cfg config.Config, obs *observability.Manager, logger *zap.Logger) (Client, error) {
 	topic := cfg.Messaging.Kafka.Topic
 
+	mechanism, err := newSASLMechanism(cfg.Messaging.Kafka.SASL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The writer has no fixed topic; PublishMessage sets one on every message.
 	writer := &kafka.Writer{
+		Transport:    newKafkaTransport(cfg.Messaging.Kafka, mechanism),
 		Addr:         kafka.TCP(cfg.Messaging.Kafka.Brokers...),
-		Topic:        topic,
 		Balancer:     &kafka.LeastBytes{},
 		RequiredAcks: kafka.RequireAll,
 		Async:        false,
@@ -140,19 +477,47 @@ func newKafkaClient(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Cli
 	readerConfig := kafka.ReaderConfig{
 		Brokers:        cfg.Messaging.Kafka.Brokers,
 		GroupID:        cfg.Messaging.ConsumerGroup,
-		Topic:          topic,
 		MinBytes:       cfg.Messaging.Kafka.MinBytes,
 		MaxBytes:       cfg.Messaging.Kafka.MaxBytes,
 		CommitInterval: cfg.Messaging.Kafka.CommitInterval,
-		Dialer: &kafka.Dialer{
-			Timeout:  cfg.Messaging.Kafka.ConnectTimeout,
-			ClientID: cfg.Messaging.Kafka.ClientID,
-		},
+		QueueCapacity:  cfg.Messaging.Kafka.QueueCapacity,
+		Dialer:         newKafkaDialer(cfg.Messaging.Kafka, mechanism),
+	}
+
+	if cfg.Messaging.Kafka.StartOffset == config.KafkaOffsetLast {
+		readerConfig.StartOffset = kafka.LastOffset
+	}
+
+	codec, err := newPayloadCodec(cfg.Messaging.Kafka.Payload)
+	if err != nil {
+		return nil, err
 	}
 
-	reader := kafka.NewReader(readerConfig)
+	client := &kafkaClient{
+		writer:       writer,
+		readerConfig: readerConfig,
+		topics:       config.MergeTopics(topic, cfg.Messaging.Kafka.Topics...),
+		topic:        topic,
+		codec:        codec,
+		retry:        cfg.Messaging.Kafka.Retry,
+		dlq:          cfg.Messaging.Kafka.DLQ,
+		propagator:   obs.Propagator(),
+		logger:       logger,
+	}
+
+	if cfg.Messaging.Kafka.ValidateOnStart {
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				dial := kafkaBrokerDialer(readerConfig.Dialer)
+				if err := validateBrokers(ctx, cfg.Messaging.Kafka.Brokers, cfg.Messaging.Kafka.ConnectTimeout, dial); err != nil {
+					return fmt.Errorf("KAFKA_VALIDATE_ON_START: %w", err)
+				}
+				logger.Info("kafka brokers reachable", zap.Strings("brokers", cfg.Messaging.Kafka.Brokers))
 
-	client := &kafkaClient{writer: writer, reader: reader, topic: topic, logger: logger}
+				return nil
+			},
+		})
+	}
 
 	lc.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
@@ -161,7 +526,16 @@ func newKafkaClient(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (Cli
 			if err := writer.Close(); err != nil {
 				return err
 			}
-			return reader.Close()
+			client.mu.Lock()
+			defer client.mu.Unlock()
+			var errs []error
+			for _, reader := range client.topicReaders {
+				errs = append(errs, reader.Close())
+			}
+			if client.reader != nil {
+				errs = append(errs, client.reader.Close())
+			}
+			return errors.Join(errs...)
 		},
 	})
 
@@ -0,0 +1,270 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// fakeWriter records written messages and fails the first failures writes.
+type fakeWriter struct {
+	mu       sync.Mutex
+	failures int
+	written  []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("broker unavailable")
+	}
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) messages() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]kafka.Message(nil), w.written...)
+}
+
+// fakeCommitter records committed messages.
+type fakeCommitter struct {
+	mu        sync.Mutex
+	committed []kafka.Message
+}
+
+func (c *fakeCommitter) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.committed = append(c.committed, msgs...)
+	return nil
+}
+
+func (c *fakeCommitter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.committed)
+}
+
+func newTestClient(writer messageWriter, dlq bool) *kafkaClient {
+	return &kafkaClient{
+		writer:     writer,
+		topic:      "orders",
+		retry:      config.KafkaRetry{MaxRetries: 2, BackoffBase: time.Millisecond, BackoffMax: 2 * time.Millisecond},
+		dlq:        config.KafkaDLQ{Enabled: dlq, Topic: "orders.dlq"},
+		propagator: propagation.TraceContext{},
+		logger:     zap.NewNop(),
+	}
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestProcessDeadLettersAfterRetries(t *testing.T) {
+	writer := &fakeWriter{}
+	reader := &fakeCommitter{}
+	client := newTestClient(writer, true)
+
+	calls := 0
+	handler := func(context.Context, Message) error {
+		calls++
+		return errors.New("downstream failed")
+	}
+	msg := kafka.Message{Topic: "orders", Partition: 1, Offset: 42, Key: []byte("k"), Value: []byte("v")}
+	client.process(context.Background(), reader, msg, handler)
+
+	if calls != 3 {
+		t.Fatalf("handler calls = %d, want 3", calls)
+	}
+	written := writer.messages()
+	if len(written) != 1 || written[0].Topic != "orders.dlq" {
+		t.Fatalf("DLQ writes = %+v, want one message on orders.dlq", written)
+	}
+	if got := headerValue(written[0], HeaderDLQAttempts); got != "3" {
+		t.Fatalf("%s = %q, want 3", HeaderDLQAttempts, got)
+	}
+	if got := headerValue(written[0], HeaderDLQOriginalOffset); got != "42" {
+		t.Fatalf("%s = %q, want 42", HeaderDLQOriginalOffset, got)
+	}
+	if reader.count() != 1 {
+		t.Fatalf("commits = %d, want 1", reader.count())
+	}
+}
+
+func TestProcessRetriesFailedDeadLetterWrite(t *testing.T) {
+	writer := &fakeWriter{failures: 3}
+	reader := &fakeCommitter{}
+	client := newTestClient(writer, true)
+
+	handler := func(context.Context, Message) error { return errors.New("downstream failed") }
+	client.process(context.Background(), reader, kafka.Message{Topic: "orders"}, handler)
+
+	if len(writer.messages()) != 1 {
+		t.Fatalf("DLQ writes = %d, want 1 after the write recovered", len(writer.messages()))
+	}
+	if reader.count() != 1 {
+		t.Fatalf("commits = %d, want 1", reader.count())
+	}
+}
+
+func TestProcessWithoutDLQHoldsUntilHandled(t *testing.T) {
+	writer := &fakeWriter{}
+	reader := &fakeCommitter{}
+	client := newTestClient(writer, false)
+
+	calls := 0
+	handler := func(context.Context, Message) error {
+		if calls++; calls < 5 {
+			return errors.New("downstream failed")
+		}
+		return nil
+	}
+	client.process(context.Background(), reader, kafka.Message{Topic: "orders"}, handler)
+
+	if calls != 5 {
+		t.Fatalf("handler calls = %d, want 5", calls)
+	}
+	if reader.count() != 1 {
+		t.Fatalf("commits = %d, want 1", reader.count())
+	}
+	if len(writer.messages()) != 0 {
+		t.Fatalf("DLQ writes = %d with the DLQ disabled", len(writer.messages()))
+	}
+}
+
+func TestProcessWithoutDLQLeavesMessageUncommittedOnShutdown(t *testing.T) {
+	reader := &fakeCommitter{}
+	client := newTestClient(&fakeWriter{}, false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	handler := func(context.Context, Message) error { return errors.New("downstream failed") }
+	client.process(ctx, reader, kafka.Message{Topic: "orders"}, handler)
+
+	if reader.count() != 0 {
+		t.Fatalf("commits = %d, want the failed message left uncommitted", reader.count())
+	}
+}
+
+func TestPublishMessageHeadersRoundTrip(t *testing.T) {
+	writer := &fakeWriter{}
+	client := newTestClient(writer, false)
+
+	sent := Message{Key: []byte("order-1"), Value: []byte(`{"id":1}`), Headers: map[string]string{
+		"content-type":   "application/json",
+		"schema-version": "2",
+	}}
+	if err := client.PublishMessage(context.Background(), sent); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	written := writer.messages()
+	if len(written) != 1 {
+		t.Fatalf("writes = %d, want 1", len(written))
+	}
+	if written[0].Topic != "orders" {
+		t.Fatalf("topic = %q, want the default topic", written[0].Topic)
+	}
+
+	var received Message
+	handler := func(_ context.Context, msg Message) error {
+		received = msg
+		return nil
+	}
+	client.process(context.Background(), &fakeCommitter{}, written[0], handler)
+
+	for key, want := range sent.Headers {
+		if got := received.Headers[key]; got != want {
+			t.Errorf("header %q = %q, want %q", key, got, want)
+		}
+	}
+	if string(received.Value) != string(sent.Value) {
+		t.Errorf("value = %q, want %q", received.Value, sent.Value)
+	}
+}
+
+func TestPublishMessageHonorsTopic(t *testing.T) {
+	writer := &fakeWriter{}
+	client := newTestClient(writer, false)
+
+	if err := client.PublishMessage(context.Background(), Message{Topic: "orders.created", Value: []byte("v")}); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	if got := writer.messages()[0].Topic; got != "orders.created" {
+		t.Fatalf("topic = %q, want orders.created", got)
+	}
+}
+
+func TestProcessRetriesInPlaceThenCommitsOnce(t *testing.T) {
+	writer := &fakeWriter{}
+	reader := &fakeCommitter{}
+	client := newTestClient(writer, true)
+
+	calls := 0
+	handler := func(context.Context, Message) error {
+		if calls++; calls <= 2 {
+			return errors.New("downstream failed")
+		}
+		return nil
+	}
+	client.process(context.Background(), reader, kafka.Message{Topic: "orders", Offset: 7}, handler)
+
+	if calls != 3 {
+		t.Fatalf("handler calls = %d, want 3", calls)
+	}
+	if reader.count() != 1 || reader.committed[0].Offset != 7 {
+		t.Fatalf("commits = %+v, want offset 7 committed once", reader.committed)
+	}
+	if len(writer.messages()) != 0 {
+		t.Fatalf("DLQ writes = %d for a message that eventually succeeded", len(writer.messages()))
+	}
+}
+
+func TestPublishMessageInjectsTraceContext(t *testing.T) {
+	writer := &fakeWriter{}
+	client := newTestClient(writer, false)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+	if err := client.PublishMessage(ctx, Message{Value: []byte("v")}); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	written := writer.messages()[0]
+	if headerValue(written, "traceparent") == "" {
+		t.Fatal("no traceparent header written")
+	}
+
+	var extracted trace.SpanContext
+	client.process(context.Background(), &fakeCommitter{}, written, func(_ context.Context, msg Message) error {
+		consumerCtx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier(msg.Headers))
+		extracted = trace.SpanContextFromContext(consumerCtx)
+		return nil
+	})
+	if !extracted.IsValid() || !extracted.IsRemote() {
+		t.Fatalf("extracted span context %+v, want a valid remote one", extracted)
+	}
+	if extracted.TraceID() != parent.TraceID() || extracted.SpanID() != parent.SpanID() {
+		t.Fatalf("extracted trace %s/%s, want %s/%s", extracted.TraceID(), extracted.SpanID(), parent.TraceID(), parent.SpanID())
+	}
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// RawClient is implemented by clients that can move messages exactly as they
+// are stored on the broker, for tools such as DLQ replay that must neither
+// decode nor re-encode payloads.
+type RawClient interface {
+	// ConsumeRaw runs handler once per message with its value and headers as
+	// stored, committing the message when handler succeeds. Nothing is retried
+	// or dead-lettered: a handler error is returned with the message left
+	// uncommitted, ending the loop.
+	ConsumeRaw(ctx context.Context, handler Handler) error
+	// PublishRaw writes msg to msg.Topic (the default topic when empty) with
+	// its value and headers, including HeaderPayloadEncoding, left untouched.
+	PublishRaw(ctx context.Context, msg Message) error
+}
+
+func (k *kafkaClient) ConsumeRaw(ctx context.Context, handler Handler) error {
+	k.startReader()
+
+	for {
+		msg, err := k.reader.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			k.logger.Error("kafka fetch failed", zap.Error(err))
+
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if err := handler(ctx, fromKafka(msg)); err != nil {
+			return fmt.Errorf("%s partition %d offset %d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+		if err := k.reader.CommitMessages(ctx, msg); err != nil {
+			return fmt.Errorf("commit %s partition %d offset %d: %w", msg.Topic, msg.Partition, msg.Offset, err)
+		}
+	}
+}
+
+func (k *kafkaClient) PublishRaw(ctx context.Context, msg Message) error {
+	topic := msg.Topic
+	if topic == "" {
+		topic = k.topic
+	}
+	out := kafka.Message{Topic: topic, Key: msg.Key, Value: msg.Value}
+	for key, value := range msg.Headers {
+		out.Headers = append(out.Headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	return k.writer.WriteMessages(ctx, out)
+}
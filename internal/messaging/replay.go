@@ -0,0 +1,130 @@
+package messaging
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// HeaderDLQReplays counts how many times a message has been replayed out of
+// the DLQ. It survives the next dead-lettering so the replay cap holds.
+const HeaderDLQReplays = "dlq-replays"
+
+// dlqHeaderPrefix marks the metadata deadLetter adds.
+const dlqHeaderPrefix = "dlq-"
+
+// ReplayOptions tunes a Replayer.
+type ReplayOptions struct {
+	// MaxReplays skips messages already replayed this many times; 0 disables the cap.
+	MaxReplays int
+	// Rate caps re-publishes per second; 0 disables the limit.
+	Rate float64
+	// DryRun logs what would be replayed without publishing.
+	DryRun bool
+}
+
+// ReplayStats counts what a Replayer did with the messages it saw.
+type ReplayStats struct {
+	Replayed int
+	Skipped  int
+}
+
+// Replayer re-publishes dead-lettered messages to the topic they came from,
+// payloads as stored. Use Handle as the Handler for RawClient.ConsumeRaw on the
+// DLQ topic, so a payload that cannot be decoded is replayed verbatim too.
+type Replayer struct {
+	client  RawClient
+	opts    ReplayOptions
+	limiter *rate.Limiter
+	logger  *zap.Logger
+	stats   ReplayStats
+}
+
+// NewReplayer builds a Replayer publishing through client.
+func NewReplayer(client RawClient, opts ReplayOptions, logger *zap.Logger) *Replayer {
+	limit := rate.Inf
+	if opts.Rate > 0 {
+		limit = rate.Limit(opts.Rate)
+	}
+	return &Replayer{
+		client:  client,
+		opts:    opts,
+		limiter: rate.NewLimiter(limit, 1),
+		logger:  logger,
+	}
+}
+
+// Stats reports the messages handled so far. Handle is not safe for concurrent
+// use, so neither is Stats while a consume loop is running.
+func (r *Replayer) Stats() ReplayStats { return r.stats }
+
+// Handle re-publishes msg to its original topic with the DLQ metadata removed.
+// Messages without an original topic or over the replay cap are skipped (and
+// therefore committed); a publish error is returned, which ends ConsumeRaw
+// with the DLQ offset uncommitted so the message is tried again on the next run.
+func (r *Replayer) Handle(ctx context.Context, msg Message) error {
+	origin := msg.Headers[HeaderDLQOriginalTopic]
+	if origin == "" {
+		r.skip("dead-lettered message has no original topic; skipping", msg)
+
+		return nil
+	}
+	replays, _ := strconv.Atoi(msg.Headers[HeaderDLQReplays])
+	if r.opts.MaxReplays > 0 && replays >= r.opts.MaxReplays {
+		r.skip("dead-lettered message reached the replay cap; skipping", msg, zap.Int("replays", replays))
+
+		return nil
+	}
+
+	out := Message{
+		Topic:   origin,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: ReplayHeaders(msg.Headers, replays+1),
+	}
+	fields := []zap.Field{
+		zap.String("origin_topic", origin),
+		zap.String("original_offset", msg.Headers[HeaderDLQOriginalOffset]),
+		zap.String("error", msg.Headers[HeaderDLQError]),
+		zap.Int("replay", replays+1),
+	}
+	if r.opts.DryRun {
+		r.stats.Replayed++
+		r.logger.Info("dry run: would replay dead-lettered message", fields...)
+
+		return nil
+	}
+
+	if err := r.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	if err := r.client.PublishRaw(ctx, out); err != nil {
+		return err
+	}
+	r.stats.Replayed++
+	r.logger.Info("dead-lettered message replayed", fields...)
+
+	return nil
+}
+
+func (r *Replayer) skip(reason string, msg Message, fields ...zap.Field) {
+	r.stats.Skipped++
+	r.logger.Warn(reason, append(fields, zap.String("topic", msg.Topic), zap.Int64("offset", msg.Offset))...)
+}
+
+// ReplayHeaders copies headers without the DLQ metadata, recording replays
+// as the message's replay count.
+func ReplayHeaders(headers map[string]string, replays int) map[string]string {
+	out := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if strings.HasPrefix(key, dlqHeaderPrefix) {
+			continue
+		}
+		out[key] = value
+	}
+	out[HeaderDLQReplays] = strconv.Itoa(replays)
+	return out
+}
@@ -0,0 +1,149 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// deadLettered runs msg through a failing handler and returns what landed on
+// the DLQ, as ConsumeRaw hands it to the replayer.
+func deadLettered(t *testing.T, msg kafka.Message) Message {
+	t.Helper()
+	return fromKafka(deadLetterWrite(t, newTestClient(&fakeWriter{}, true), msg))
+}
+
+// deadLetterWrite runs msg through client with a failing handler and returns
+// the message written to the DLQ.
+func deadLetterWrite(t *testing.T, client *kafkaClient, msg kafka.Message) kafka.Message {
+	t.Helper()
+	client.process(context.Background(), &fakeCommitter{}, msg, func(context.Context, Message) error {
+		return errors.New("downstream failed")
+	})
+	written := client.writer.(*fakeWriter).messages()
+	if len(written) != 1 {
+		t.Fatalf("DLQ writes = %d, want 1", len(written))
+	}
+	return written[0]
+}
+
+func TestReplayerRepublishesToOriginWithCleanHeaders(t *testing.T) {
+	dlq := deadLettered(t, kafka.Message{
+		Topic:   "orders.created",
+		Key:     []byte("order-1"),
+		Value:   []byte(`{"id":1}`),
+		Headers: []kafka.Header{{Key: "content-type", Value: []byte("application/json")}},
+	})
+	writer := &fakeWriter{}
+	replayer := NewReplayer(newTestClient(writer, false), ReplayOptions{MaxReplays: 3}, zap.NewNop())
+
+	if err := replayer.Handle(context.Background(), dlq); err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	written := writer.messages()
+	if len(written) != 1 {
+		t.Fatalf("writes = %d, want 1", len(written))
+	}
+	out := written[0]
+	if out.Topic != "orders.created" || string(out.Key) != "order-1" || string(out.Value) != `{"id":1}` {
+		t.Fatalf("replayed %s %q %q, want the original topic, key, and value", out.Topic, out.Key, out.Value)
+	}
+	for _, h := range out.Headers {
+		if strings.HasPrefix(h.Key, dlqHeaderPrefix) && h.Key != HeaderDLQReplays {
+			t.Errorf("replayed message kept DLQ header %s", h.Key)
+		}
+	}
+	if got := headerValue(out, HeaderDLQReplays); got != "1" {
+		t.Errorf("%s = %q, want 1", HeaderDLQReplays, got)
+	}
+	if got := headerValue(out, "content-type"); got != "application/json" {
+		t.Errorf("content-type = %q, want it preserved", got)
+	}
+	if stats := replayer.Stats(); stats.Replayed != 1 || stats.Skipped != 0 {
+		t.Errorf("stats = %+v, want one replayed", stats)
+	}
+}
+
+func TestReplayerSkipsCappedAndDryRunPublishesNothing(t *testing.T) {
+	writer := &fakeWriter{}
+	capped := Message{Topic: "orders.dlq", Headers: map[string]string{HeaderDLQOriginalTopic: "orders", HeaderDLQReplays: "3"}}
+	replayer := NewReplayer(newTestClient(writer, false), ReplayOptions{MaxReplays: 3}, zap.NewNop())
+	if err := replayer.Handle(context.Background(), capped); err != nil {
+		t.Fatalf("Handle capped: %v", err)
+	}
+
+	fresh := Message{Topic: "orders.dlq", Headers: map[string]string{HeaderDLQOriginalTopic: "orders"}}
+	dryRun := NewReplayer(newTestClient(writer, false), ReplayOptions{DryRun: true}, zap.NewNop())
+	if err := dryRun.Handle(context.Background(), fresh); err != nil {
+		t.Fatalf("Handle dry run: %v", err)
+	}
+
+	if len(writer.messages()) != 0 {
+		t.Fatalf("writes = %d, want none", len(writer.messages()))
+	}
+	if replayer.Stats().Skipped != 1 || dryRun.Stats().Replayed != 1 {
+		t.Fatalf("stats = %+v and %+v, want one skipped and one dry-run replay", replayer.Stats(), dryRun.Stats())
+	}
+}
+
+func TestReplayUndecodableEntryRepublishesVerbatim(t *testing.T) {
+	// The payload was encrypted under a key the consumer no longer has, so it
+	// was dead-lettered without reaching a handler.
+	producer := newTestClient(&fakeWriter{}, false)
+	producer.codec = newTestCodec(t, 1)
+	if err := producer.PublishMessage(context.Background(), Message{Topic: "orders", Value: []byte(`{"id":1}`)}); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	original := producer.writer.(*fakeWriter).messages()[0]
+	consumer := newTestClient(&fakeWriter{}, true)
+	consumer.codec = newTestCodec(t, 2)
+	dead := deadLetterWrite(t, consumer, original)
+
+	reader := &fakeReader{queue: make(chan kafka.Message, 1)}
+	reader.queue <- dead
+	dlq := newTestClient(&fakeWriter{}, false)
+	dlq.readerOnce.Do(func() {})
+	dlq.reader = reader
+	replayer := NewReplayer(dlq, ReplayOptions{MaxReplays: 3}, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := dlq.ConsumeRaw(ctx, replayer.Handle); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ConsumeRaw = %v, want it to keep consuming until the deadline", err)
+	}
+
+	written := dlq.writer.(*fakeWriter).messages()
+	if len(written) != 1 || written[0].Topic != "orders" || !bytes.Equal(written[0].Value, original.Value) {
+		t.Fatalf("replayed %+v, want the encoded payload re-published to orders", written)
+	}
+	if got, want := headerValue(written[0], HeaderPayloadEncoding), headerValue(original, HeaderPayloadEncoding); got != want {
+		t.Fatalf("%s = %q, want %q kept", HeaderPayloadEncoding, got, want)
+	}
+	if reader.count() != 1 {
+		t.Fatalf("commits = %d, want the DLQ entry committed", reader.count())
+	}
+}
+
+func TestConsumeRawStopsOnPublishFailureWithoutCommitting(t *testing.T) {
+	reader := &fakeReader{queue: make(chan kafka.Message, 2)}
+	reader.queue <- deadLetterWrite(t, newTestClient(&fakeWriter{}, true), kafka.Message{Topic: "orders", Value: []byte("v")})
+	dlq := newTestClient(&fakeWriter{failures: 1}, false)
+	dlq.readerOnce.Do(func() {})
+	dlq.reader = reader
+	replayer := NewReplayer(dlq, ReplayOptions{}, zap.NewNop())
+
+	err := dlq.ConsumeRaw(context.Background(), replayer.Handle)
+	if err == nil || !strings.Contains(err.Error(), "broker unavailable") {
+		t.Fatalf("ConsumeRaw = %v, want the publish error", err)
+	}
+	if reader.count() != 0 {
+		t.Fatalf("commits = %d, want the failed entry left uncommitted", reader.count())
+	}
+}
@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Tailer is implemented by clients that can follow a topic without a consumer
+// group, for debugging tools that must never commit, retry, dead-letter, or
+// publish.
+type Tailer interface {
+	// Tail runs handler on every message on topic, value and headers as
+	// stored, starting at the newest offset unless fromBeginning is set. It
+	// returns when ctx is done or handler fails.
+	Tail(ctx context.Context, topic string, fromBeginning bool, handler Handler) error
+}
+
+// Tail reads each partition of topic on its own group-less reader, so no
+// consumer group is created and no offset is committed.
+func (k *kafkaClient) Tail(ctx context.Context, topic string, fromBeginning bool, handler Handler) error {
+	partitions, err := k.lookupPartitions(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	readers := make([]messageReader, 0, len(partitions))
+	defer func() {
+		for _, reader := range readers {
+			_ = reader.Close()
+		}
+	}()
+	for _, partition := range partitions {
+		readerConfig := k.readerConfig
+		readerConfig.GroupID = ""
+		readerConfig.GroupTopics = nil
+		readerConfig.Topic = topic
+		readerConfig.Partition = partition.ID
+		readerConfig.CommitInterval = 0
+		readerConfig.StartOffset = 0
+		reader := kafka.NewReader(readerConfig)
+		readers = append(readers, reader)
+		if !fromBeginning {
+			if err := reader.SetOffset(kafka.LastOffset); err != nil {
+				return err
+			}
+		}
+	}
+	return tailReaders(ctx, readers, handler)
+}
+
+func (k *kafkaClient) lookupPartitions(ctx context.Context, topic string) ([]kafka.Partition, error) {
+	errs := make([]error, 0, len(k.readerConfig.Brokers))
+	for _, broker := range k.readerConfig.Brokers {
+		partitions, err := k.readerConfig.Dialer.LookupPartitions(ctx, "tcp", broker, topic)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("broker %s: %w", broker, err))
+			continue
+		}
+		if len(partitions) == 0 {
+			return nil, fmt.Errorf("topic %s has no partitions", topic)
+		}
+		return partitions, nil
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no kafka brokers configured")
+	}
+	return nil, errors.Join(errs...)
+}
+
+// tailReaders fans the readers' messages into handler one at a time until a
+// fetch or handler fails. Nothing is committed.
+func tailReaders(ctx context.Context, readers []messageReader, handler Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	msgs := make(chan kafka.Message)
+	errs := make(chan error, len(readers))
+	for _, reader := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				msg, err := reader.FetchMessage(ctx)
+				if err != nil {
+					errs <- err
+					return
+				}
+				select {
+				case msgs <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		select {
+		case msg := <-msgs:
+			if err := handler(ctx, fromKafka(msg)); err != nil {
+				return err
+			}
+		case err := <-errs:
+			return err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestTailReadersDeliversEveryPartitionWithoutCommitting(t *testing.T) {
+	readers := make([]*fakeReader, 2)
+	for i := range readers {
+		readers[i] = &fakeReader{queue: make(chan kafka.Message, 1)}
+		readers[i].queue <- kafka.Message{
+			Topic:     "orders.dlq",
+			Partition: i,
+			Value:     []byte("still encoded"),
+			Headers:   []kafka.Header{{Key: HeaderPayloadEncoding, Value: []byte("gzip+aes-gcm")}},
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	seen := map[int]Message{}
+	handler := func(_ context.Context, msg Message) error {
+		seen[msg.Partition] = msg
+		if len(seen) == len(readers) {
+			cancel()
+		}
+		return nil
+	}
+	err := tailReaders(ctx, []messageReader{readers[0], readers[1]}, handler)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("tailReaders = %v, want context.Canceled", err)
+	}
+
+	for partition := range readers {
+		msg, ok := seen[partition]
+		if !ok {
+			t.Fatalf("partition %d not delivered", partition)
+		}
+		if string(msg.Value) != "still encoded" || msg.Headers[HeaderPayloadEncoding] != "gzip+aes-gcm" {
+			t.Fatalf("partition %d delivered %+v, want the payload as stored", partition, msg)
+		}
+		if readers[partition].count() != 0 {
+			t.Fatalf("partition %d committed %d messages, want none", partition, readers[partition].count())
+		}
+	}
+}
+
+func TestTailReadersStopsOnHandlerError(t *testing.T) {
+	reader := &fakeReader{queue: make(chan kafka.Message, 1)}
+	reader.queue <- kafka.Message{Topic: "orders"}
+	failed := errors.New("stdout closed")
+
+	err := tailReaders(context.Background(), []messageReader{reader}, func(context.Context, Message) error { return failed })
+	if !errors.Is(err, failed) {
+		t.Fatalf("tailReaders = %v, want the handler error", err)
+	}
+}
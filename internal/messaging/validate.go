@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// brokerDialer opens a connection to one broker; validateBrokers only needs
+// to know whether it succeeds.
+type brokerDialer func(ctx context.Context, broker string) error
+
+// kafkaBrokerDialer dials with the client's authenticated dialer.
+func kafkaBrokerDialer(dialer *kafka.Dialer) brokerDialer {
+	return func(ctx context.Context, broker string) error {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// validateBrokers dials every broker concurrently, each within timeout, and
+// returns an error naming all that could not be reached.
+func validateBrokers(ctx context.Context, brokers []string, timeout time.Duration, dial brokerDialer) error {
+	failures := make([]error, len(brokers))
+	var wg sync.WaitGroup
+	for i, broker := range brokers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dialCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				dialCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			failures[i] = dial(dialCtx, broker)
+		}()
+	}
+	wg.Wait()
+
+	var unreachable []string
+	for i, err := range failures {
+		if err != nil {
+			unreachable = append(unreachable, fmt.Sprintf("%s (%v)", brokers[i], err))
+		}
+	}
+	if len(unreachable) > 0 {
+		return fmt.Errorf("kafka brokers unreachable: %s", strings.Join(unreachable, ", "))
+	}
+	return nil
+}
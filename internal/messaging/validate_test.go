@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateBrokersNamesEveryUnreachableBroker(t *testing.T) {
+	down := map[string]bool{"kafka-2:9092": true, "kafka-3:9092": true}
+	dial := func(ctx context.Context, broker string) error {
+		if down[broker] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	brokers := []string{"kafka-1:9092", "kafka-2:9092", "kafka-3:9092"}
+	err := validateBrokers(context.Background(), brokers, time.Second, dial)
+	if err == nil {
+		t.Fatal("validateBrokers succeeded with two brokers down")
+	}
+	for broker := range down {
+		if !strings.Contains(err.Error(), broker+" (connection refused)") {
+			t.Errorf("error %q does not name %s", err, broker)
+		}
+	}
+	if strings.Contains(err.Error(), "kafka-1:9092") {
+		t.Errorf("error %q names the reachable broker", err)
+	}
+
+	if err := validateBrokers(context.Background(), brokers[:1], time.Second, dial); err != nil {
+		t.Fatalf("validateBrokers with every broker up: %v", err)
+	}
+}
+
+func TestValidateBrokersBoundsEachDial(t *testing.T) {
+	hang := func(ctx context.Context, _ string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	start := time.Now()
+	err := validateBrokers(context.Background(), []string{"kafka-1:9092", "kafka-2:9092"}, 20*time.Millisecond, hang)
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("error = %v, want both brokers timed out", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("validation took %s, want dials bounded by the timeout and run concurrently", elapsed)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pressly/goose/v3"
+	goosedb "github.com/pressly/goose/v3/database"
 	"github.com/uptrace/bun"
 	"go.uber.org/zap"
 
@@ -18,10 +22,22 @@ const migrationsDir = "db/migrations/sql"
 
 // Migrator wraps goose operations.
 type Migrator struct {
-	db     *bun.DB
+	db      *bun.DB
+	dialect string
+	// dir holds the goose migration files; migrationsDir outside tests.
+	dir    string
 	logger *zap.Logger
 }
 
+// MigrationStatus describes one migration file and whether it has been applied.
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+	// AppliedAt is zero for pending migrations.
+	AppliedAt time.Time
+}
+
 // New constructs a goose-backed migrator.
 func New(cfg config.Config, conns *database.Connections, logger *zap.Logger) (*Migrator, error) {
 	dialect, err := gooseDialect(cfg.Database.Driver)
@@ -34,14 +50,16 @@ func New(cfg config.Config, conns *database.Connections, logger *zap.Logger) (*M
 	}
 
 	return &Migrator{
-		db:     conns.Writer,
-		logger: logger,
+		db:      conns.Writer,
+		dialect: dialect,
+		dir:     migrationsDir,
+		logger:  logger,
 	}, nil
 }
 
 // Up applies all pending migrations.
 func (m *Migrator) Up(ctx context.Context) error {
-	if err := goose.UpContext(ctx, m.db.DB, migrationsDir); err != nil {
+	if err := goose.UpContext(ctx, m.db.DB, m.dir); err != nil {
 		if isNoMigrationErr(err) {
 			m.logger.Info("no migrations to apply")
 
@@ -55,10 +73,74 @@ func (m *Migrator) Up(ctx context.Context) error {
 	return nil
 }
 
+// MigrateTo moves the schema up or down to exactly version, which must be 0
+// (everything rolled back) or a migration in the migrations directory. It returns the
+// version the schema was at before; when that equals version nothing runs.
+func (m *Migrator) MigrateTo(ctx context.Context, version int64) (int64, error) {
+	if version < 0 {
+		return 0, fmt.Errorf("target version must not be negative: %d", version)
+	}
+	if version > 0 {
+		migrations, err := goose.CollectMigrations(m.dir, 0, goose.MaxVersion)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := migrations.Current(version); err != nil {
+			return 0, fmt.Errorf("no migration with version %d in %s", version, m.dir)
+		}
+	}
+
+	current, err := goose.GetDBVersionContext(ctx, m.db.DB)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case current == version:
+		m.logger.Info("schema already at target version", zap.Int64("version", version))
+
+		return current, nil
+	case current < version:
+		err = goose.UpToContext(ctx, m.db.DB, m.dir, version)
+	default:
+		err = goose.DownToContext(ctx, m.db.DB, m.dir, version)
+	}
+	if err != nil {
+		return current, err
+	}
+
+	m.logger.Info("schema migrated", zap.Int64("from", current), zap.Int64("to", version))
+
+	return current, nil
+}
+
+// Status lists every migration in the migrations directory in version order with when it
+// was applied, or as pending.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	provider, err := goose.NewProvider(goosedb.Dialect(m.dialect), m.db.DB, os.DirFS(m.dir))
+	if err != nil {
+		return nil, err
+	}
+	results, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(results))
+	for _, result := range results {
+		statuses = append(statuses, MigrationStatus{
+			Version:   result.Source.Version,
+			Name:      filepath.Base(result.Source.Path),
+			Applied:   result.State == goose.StateApplied,
+			AppliedAt: result.AppliedAt,
+		})
+	}
+	return statuses, nil
+}
+
 // Down rolls back migrations. Steps <=0 defaults to 1; all=true rolls everything back.
 func (m *Migrator) Down(ctx context.Context, steps int, all bool) error {
 	if all {
-		if err := goose.DownToContext(ctx, m.db.DB, migrationsDir, 0); err != nil {
+		if err := goose.DownToContext(ctx, m.db.DB, m.dir, 0); err != nil {
 			if isNoMigrationErr(err) {
 				m.logger.Info("no migrations to rollback")
 
@@ -76,7 +158,7 @@ func (m *Migrator) Down(ctx context.Context, steps int, all bool) error {
 	}
 
 	for i := 0; i < steps; i++ {
-		if err := goose.DownContext(ctx, m.db.DB, migrationsDir); err != nil {
+		if err := goose.DownContext(ctx, m.db.DB, m.dir); err != nil {
 			if isNoMigrationErr(err) {
 				m.logger.Info("no migrations to rollback")
 
@@ -0,0 +1,114 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+)
+
+// testMigrations creates one table per version so each step is visible.
+var testMigrations = map[string]string{
+	"00001_create_a.sql": "-- +goose Up\nCREATE TABLE a (id INTEGER);\n-- +goose Down\nDROP TABLE a;\n",
+	"00002_create_b.sql": "-- +goose Up\nCREATE TABLE b (id INTEGER);\n-- +goose Down\nDROP TABLE b;\n",
+	"00003_create_c.sql": "-- +goose Up\nCREATE TABLE c (id INTEGER);\n-- +goose Down\nDROP TABLE c;\n",
+}
+
+// newTestMigrator builds a Migrator over a fresh SQLite database reading
+// testMigrations from a temporary directory.
+func newTestMigrator(t *testing.T) *Migrator {
+	t.Helper()
+	dir := t.TempDir()
+	for name, body := range testMigrations {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	var cfg config.Config
+	cfg.Database.Driver = "sqlite"
+	m, err := New(cfg, dbtest.Connections(dbtest.New(t)), zap.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	m.dir = dir
+	return m
+}
+
+func TestStatusListsAppliedAndPendingMigrations(t *testing.T) {
+	m := newTestMigrator(t)
+	if err := goose.UpToContext(t.Context(), m.db.DB, m.dir, 1); err != nil {
+		t.Fatalf("apply first migration: %v", err)
+	}
+
+	statuses, err := m.Status(t.Context())
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if len(statuses) != 3 {
+		t.Fatalf("statuses = %+v, want 3", statuses)
+	}
+	for i, status := range statuses {
+		version := int64(i + 1)
+		applied := version == 1
+		if status.Version != version || status.Applied != applied || status.AppliedAt.IsZero() == applied {
+			t.Errorf("status %d = %+v, want version %d applied=%t", i, status, version, applied)
+		}
+	}
+	if statuses[0].Name != "00001_create_a.sql" {
+		t.Errorf("name = %q, want the file name", statuses[0].Name)
+	}
+}
+
+func TestMigrateToMovesUpAndDownToVersion(t *testing.T) {
+	m := newTestMigrator(t)
+	applied := func() int {
+		t.Helper()
+		statuses, err := m.Status(t.Context())
+		if err != nil {
+			t.Fatalf("Status: %v", err)
+		}
+		n := 0
+		for _, status := range statuses {
+			if status.Applied {
+				n++
+			}
+		}
+		return n
+	}
+
+	steps := []struct {
+		target, from int64
+		applied      int
+	}{
+		{target: 2, from: 0, applied: 2},
+		{target: 2, from: 2, applied: 2},
+		{target: 3, from: 2, applied: 3},
+		{target: 1, from: 3, applied: 1},
+		{target: 0, from: 1, applied: 0},
+	}
+	for _, step := range steps {
+		from, err := m.MigrateTo(t.Context(), step.target)
+		if err != nil {
+			t.Fatalf("MigrateTo(%d): %v", step.target, err)
+		}
+		if from != step.from {
+			t.Errorf("MigrateTo(%d) reported from %d, want %d", step.target, from, step.from)
+		}
+		if got := applied(); got != step.applied {
+			t.Errorf("after MigrateTo(%d) %d migrations applied, want %d", step.target, got, step.applied)
+		}
+	}
+
+	if _, err := m.MigrateTo(t.Context(), 4); err == nil {
+		t.Fatal("MigrateTo accepted a version with no migration")
+	}
+	if _, err := m.MigrateTo(t.Context(), -1); err == nil {
+		t.Fatal("MigrateTo accepted a negative version")
+	}
+}
@@ -38,6 +38,7 @@ type Manager struct {
 	tracerProvider *sdktrace.TracerProvider
 	meterProvider  *sdkmetric.MeterProvider
 	metricsHandler http.Handler
+	propagator     propagation.TextMapPropagator
 	cfg            config.Observability
 	logger         *zap.Logger
 }
@@ -62,6 +63,10 @@ func NewManager(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (*Manage
 	}
 
 	mgr := &Manager{
+		propagator: propagation.NewCompositeTextMapPropagator(
+			propagation.TraceContext{},
+			propagation.Baggage{},
+		),
 		cfg:    cfg.Observability,
 		logger: logger,
 	}
@@ -82,10 +87,7 @@ func NewManager(lc fx.Lifecycle, cfg config.Config, logger *zap.Logger) (*Manage
 		OnStart: func(ctx context.Context) error {
 			if tp := mgr.tracerProvider; tp != nil {
 				otel.SetTracerProvider(tp)
-				otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
-					propagation.TraceContext{},
-					propagation.Baggage{},
-				))
+				otel.SetTextMapPropagator(mgr.propagator)
 			}
 			if mp := mgr.meterProvider; mp != nil {
 				otel.SetMeterProvider(mp)
@@ -125,6 +127,15 @@ func (m *Manager) MetricsHandler() http.Handler {
 	return m.metricsHandler
 }
 
+// Propagator returns the W3C trace context and baggage propagator used to
+// carry traces across process boundaries such as Kafka messages.
+func (m *Manager) Propagator() propagation.TextMapPropagator {
+	if m == nil || m.propagator == nil {
+		return otel.GetTextMapPropagator()
+	}
+	return m.propagator
+}
+
 // PrometheusPath returns the configured metrics endpoint path.
 func (m *Manager) PrometheusPath() string {
 	return m.cfg.PrometheusPath
@@ -1,13 +1,29 @@
 package response
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"reflect"
+	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
+	"github.com/Additional-Code/atlas/internal/logger"
 	"github.com/Additional-Code/atlas/pkg/errorbank"
 )
 
+const cacheControlNoStore = "no-store"
+
+// renderedErrorKey stores the AppError a Builder rendered on the echo context.
+const renderedErrorKey = "response.error"
+
+// internalErrorBody is written when even an error envelope cannot be encoded.
+var internalErrorBody = []byte(`{"success":false,"error":{"kind":"internal","message":"failed to encode response"}}`)
+
 // Builder helps construct consistent HTTP responses.
 type Builder struct {
 	ctx    echo.Context
@@ -15,6 +31,8 @@ type Builder struct {
 	data   any
 	err    error
 	meta   map[string]any
+	// cacheControl is sent on success; errors are always no-store.
+	cacheControl string
 }
 
 // New instantiates a Builder for the provided request context.
@@ -42,6 +60,13 @@ func (b *Builder) WithError(err error) *Builder {
 	return b
 }
 
+// RenderedError returns the AppError a Builder rendered for the request, if
+// any, so middleware can log errors that handlers turned into responses.
+func RenderedError(c echo.Context) *errorbank.AppError {
+	appErr, _ := c.Get(renderedErrorKey).(*errorbank.AppError)
+	return appErr
+}
+
 // WithMeta appends auxiliary metadata to the response.
 func (b *Builder) WithMeta(key string, value any) *Builder {
 	if key == "" {
@@ -54,6 +79,21 @@ func (b *Builder) WithMeta(key string, value any) *Builder {
 	return b
 }
 
+// WithCacheControl lets clients and shared caches (when public) reuse a
+// successful response for maxAge. A non-positive maxAge disables caching.
+func (b *Builder) WithCacheControl(maxAge time.Duration, public bool) *Builder {
+	if maxAge <= 0 {
+		b.cacheControl = cacheControlNoStore
+		return b
+	}
+	scope := "private"
+	if public {
+		scope = "public"
+	}
+	b.cacheControl = fmt.Sprintf("%s, max-age=%d", scope, int64(maxAge/time.Second))
+	return b
+}
+
 // Build finalises and emits the HTTP response.
 func (b *Builder) Build() error {
 	if b.err != nil {
@@ -75,15 +115,29 @@ func (b *Builder) buildSuccess() error {
 		Data:    b.data,
 		Meta:    b.meta,
 	}
-	return b.ctx.JSON(b.status, payload)
+	// Encode before writing so an unserializable payload becomes a clean 500
+	// instead of a committed status with a truncated body.
+	body, err := json.Marshal(payload)
+	if err != nil {
+		b.logEncodeFailure(err)
+		b.status = 0
+		b.err = errorbank.Internal("failed to encode response", errorbank.WithCause(err))
+		return b.buildError()
+	}
+	if b.cacheControl != "" {
+		b.ctx.Response().Header().Set(echo.HeaderCacheControl, b.cacheControl)
+	}
+	return b.ctx.JSONBlob(b.status, body)
 }
 
 func (b *Builder) buildError() error {
-	appErr := errorbank.From(b.err)
+	appErr := errorbank.From(fromValidation(b.err))
 	status := b.status
 	if status < 400 {
 		status = appErr.StatusCode()
 	}
+	b.ctx.Set(renderedErrorKey, appErr)
+	errorbank.RecordRendered(b.ctx.Request().Context(), appErr)
 	payload := struct {
 		Success bool `json:"success"`
 		Error   struct {
@@ -99,6 +153,55 @@ func (b *Builder) buildError() error {
 	payload.Error.Kind = string(appErr.Kind())
 	payload.Error.Message = appErr.Message()
 	payload.Error.Details = appErr.Details()
+	// Never let a cache hold on to a failure.
+	b.ctx.Response().Header().Set(echo.HeaderCacheControl, cacheControlNoStore)
 
-	return b.ctx.JSON(status, payload)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		b.logEncodeFailure(err)
+		return b.ctx.JSONBlob(http.StatusInternalServerError, internalErrorBody)
+	}
+	return b.ctx.JSONBlob(status, body)
+}
+
+func (b *Builder) logEncodeFailure(err error) {
+	logger.FromContext(b.ctx.Request().Context(), zap.NewNop()).Error("http response encoding failed", zap.Error(err))
+}
+
+// fromValidation converts struct-tag validation failures into a single
+// unprocessable error whose details map each field to the rule it broke.
+func fromValidation(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+	fields := make(map[string]any, len(verrs))
+	for _, fe := range verrs {
+		fields[fe.Field()] = describeRule(fe)
+	}
+	return errorbank.Unprocessable("validation failed", errorbank.WithDetails(fields), errorbank.WithCause(err))
+}
+
+func describeRule(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), lengthUnit(fe))
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), lengthUnit(fe))
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", fe.Param())
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed %s=%s", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed %s", fe.Tag())
+}
+
+func lengthUnit(fe validator.FieldError) string {
+	if fe.Kind() == reflect.String {
+		return " characters"
+	}
+	return ""
 }
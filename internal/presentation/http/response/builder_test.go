@@ -0,0 +1,42 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestBuildUnserializableDataRendersInternalError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/orders/1", nil), rec)
+
+	if err := New(c).WithStatus(http.StatusCreated).WithData(map[string]any{"bad": make(chan int)}).Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	var envelope struct {
+		Success bool `json:"success"`
+		Error   struct {
+			Kind    string `json:"kind"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("body %q is not a JSON envelope: %v", rec.Body.String(), err)
+	}
+	if envelope.Success || envelope.Error.Kind != "internal" || envelope.Error.Message != "failed to encode response" {
+		t.Fatalf("envelope = %+v, want an internal error", envelope)
+	}
+	if got := rec.Header().Get(echo.HeaderCacheControl); got != cacheControlNoStore {
+		t.Fatalf("Cache-Control = %q, want %s", got, cacheControlNoStore)
+	}
+	if RenderedError(c) == nil {
+		t.Fatal("rendered error not recorded for the error log middleware")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/uptrace/bun"
 	"go.opentelemetry.io/otel"
@@ -20,18 +21,60 @@ var repoTracer = otel.Tracer("github.com/Additional-Code/atlas/repository/order"
 // ErrNotFound is returned when an order is missing.
 var ErrNotFound = errors.New("order not found")
 
+// MaxListLimit caps the page size accepted by List.
+const MaxListLimit = 100
+
 // Repository encapsulates read/write access for orders.
 type Repository struct {
-	writer *bun.DB
-	reader *bun.DB
+	writer  bun.IDB
+	conns   *database.Connections
+	breaker *database.Breaker
+	// tx is set on repositories handed to WithTx callbacks; reads use it too
+	// so they observe the transaction's own writes.
+	tx bun.IDB
 }
 
 // NewRepository wires a repository backed by configured database connections.
-func NewRepository(conns *database.Connections) *Repository {
+// Every query runs through breaker, so an open circuit returns
+// database.ErrCircuitOpen without reaching the database.
+func NewRepository(conns *database.Connections, breaker *database.Breaker) *Repository {
 	return &Repository{
-		writer: conns.Writer,
-		reader: conns.Reader,
+		writer:  conns.Writer,
+		conns:   conns,
+		breaker: breaker,
+	}
+}
+
+// WithTx runs fn against a repository bound to a single writer transaction
+// begun with opts (nil uses the driver default), committing when fn returns
+// nil and rolling back otherwise. It goes through database.Connections.RunInTx,
+// so fn is re-run after a serialization failure. Calling WithTx on a
+// repository that is already transaction-scoped joins that transaction.
+func (r *Repository) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(*Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+	return r.conns.RunInTx(ctx, opts, func(ctx context.Context, tx bun.Tx) error {
+		scoped := *r
+		scoped.writer = tx
+		scoped.tx = tx
+		return fn(&scoped)
+	})
+}
+
+// DB returns the handle writes go through: the open transaction inside WithTx,
+// otherwise the writer. Other repositories use it to join the transaction.
+func (r *Repository) DB() bun.IDB {
+	return r.writer
+}
+
+// readDB picks the handle for reads: the transaction when scoped, otherwise
+// the connection selected by ctx's routing override.
+func (r *Repository) readDB(ctx context.Context) bun.IDB {
+	if r.tx != nil {
+		return r.tx
 	}
+	return r.conns.ReadDB(ctx)
 }
 
 // Create persists a new order using the write connection.
@@ -42,7 +85,10 @@ func (r *Repository) Create(ctx context.Context, order *entity.Order) error {
 	ctx, span := repoTracer.Start(ctx, "OrderRepository.Create", trace.WithAttributes(attribute.String("order.number", order.Number)))
 	defer span.End()
 
-	_, err := r.writer.NewInsert().Model(order).Exec(ctx)
+	err := r.breaker.Do(ctx, func(ctx context.Context) error {
+		_, err := r.writer.NewInsert().Model(order).Exec(ctx)
+		return err
+	})
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "insert failed")
@@ -50,13 +96,40 @@ func (r *Repository) Create(ctx context.Context, order *entity.Order) error {
 	return err
 }
 
-// GetByID fetches an order by primary key using the read replica when available.
+// GetByID fetches an order by primary key using the read replica when available,
+// honouring database.WithForcePrimary / WithForceReplica on ctx.
 func (r *Repository) GetByID(ctx context.Context, id int64) (*entity.Order, error) {
 	ctx, span := repoTracer.Start(ctx, "OrderRepository.GetByID", trace.WithAttributes(attribute.Int64("order.id", id)))
 	defer span.End()
 
 	order := new(entity.Order)
-	err := r.reader.NewSelect().Model(order).Where("id = ?", id).Scan(ctx)
+	err := r.breaker.Do(ctx, func(ctx context.Context) error {
+		return r.readDB(ctx).NewSelect().Model(order).Where("id = ?", id).Scan(ctx)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "not found")
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, err
+	}
+	order.BackfillUpdatedAt()
+	return order, nil
+}
+
+// GetByIDForUpdate fetches an order from the writer and row-locks it until the
+// surrounding transaction ends, so the caller sees the state its update will
+// replace. Only meaningful inside WithTx.
+func (r *Repository) GetByIDForUpdate(ctx context.Context, id int64) (*entity.Order, error) {
+	ctx, span := repoTracer.Start(ctx, "OrderRepository.GetByIDForUpdate", trace.WithAttributes(attribute.Int64("order.id", id)))
+	defer span.End()
+
+	order := new(entity.Order)
+	err := r.breaker.Do(ctx, func(ctx context.Context) error {
+		return r.writer.NewSelect().Model(order).Where("id = ?", id).For("UPDATE").Scan(ctx)
+	})
 	if errors.Is(err, sql.ErrNoRows) {
 		span.SetStatus(codes.Error, "not found")
 		return nil, ErrNotFound
@@ -66,5 +139,148 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*entity.Order, erro
 		span.SetStatus(codes.Error, "select failed")
 		return nil, err
 	}
+	order.BackfillUpdatedAt()
 	return order, nil
 }
+
+// Update persists the mutable order fields using the write connection.
+func (r *Repository) Update(ctx context.Context, order *entity.Order) error {
+	if order == nil {
+		return errors.New("nil order")
+	}
+	ctx, span := repoTracer.Start(ctx, "OrderRepository.Update", trace.WithAttributes(attribute.Int64("order.id", order.ID)))
+	defer span.End()
+
+	var res sql.Result
+	err := r.breaker.Do(ctx, func(ctx context.Context) (err error) {
+		res, err = r.writer.NewUpdate().
+			Model(order).
+			Column("number", "status", "updated_at").
+			WherePK().
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "update failed")
+		return err
+	}
+	return requireAffected(span, res)
+}
+
+// Delete removes an order by primary key using the write connection.
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	ctx, span := repoTracer.Start(ctx, "OrderRepository.Delete", trace.WithAttributes(attribute.Int64("order.id", id)))
+	defer span.End()
+
+	var res sql.Result
+	err := r.breaker.Do(ctx, func(ctx context.Context) (err error) {
+		res, err = r.writer.NewDelete().Model((*entity.Order)(nil)).Where("id = ?", id).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "delete failed")
+		return err
+	}
+	return requireAffected(span, res)
+}
+
+// requireAffected maps a write that touched no rows onto ErrNotFound.
+func requireAffected(span trace.Span, res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "rows affected unavailable")
+		return err
+	}
+	if affected == 0 {
+		span.SetStatus(codes.Error, "not found")
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List returns a page of orders ordered by id descending along with the total
+// number of matching rows. An empty status disables filtering, limit is clamped
+// to [1, MaxListLimit], and a negative offset is treated as zero.
+func (r *Repository) List(ctx context.Context, offset, limit int, status entity.OrderStatus) ([]*entity.Order, int, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 || limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	ctx, span := repoTracer.Start(ctx, "OrderRepository.List", trace.WithAttributes(
+		attribute.Int("page.offset", offset),
+		attribute.Int("page.limit", limit),
+		attribute.String("order.status", string(status)),
+	))
+	defer span.End()
+
+	orders := make([]*entity.Order, 0, limit)
+	query := r.readDB(ctx).NewSelect().Model(&orders)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int
+	err := r.breaker.Do(ctx, func(ctx context.Context) (err error) {
+		total, err = query.OrderExpr("id DESC").Limit(limit).Offset(offset).ScanAndCount(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, 0, err
+	}
+	for _, order := range orders {
+		order.BackfillUpdatedAt()
+	}
+	return orders, total, nil
+}
+
+// UpdatedCursor is a position in the (updated_at, id) order ListUpdatedSince
+// pages through.
+type UpdatedCursor struct {
+	UpdatedAt time.Time
+	ID        int64
+}
+
+// ListUpdatedSince returns up to limit orders positioned strictly after the
+// cursor, oldest change first, for incremental sync. Ordering by id within an
+// updated_at means orders sharing a timestamp are never skipped at a page
+// boundary; callers page by passing the last returned order's UpdatedAt and ID
+// back. limit is clamped to [1, MaxListLimit].
+func (r *Repository) ListUpdatedSince(ctx context.Context, after UpdatedCursor, limit int) ([]*entity.Order, error) {
+	if limit <= 0 || limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	ctx, span := repoTracer.Start(ctx, "OrderRepository.ListUpdatedSince", trace.WithAttributes(
+		attribute.String("page.after_updated_at", after.UpdatedAt.Format(time.RFC3339Nano)),
+		attribute.Int64("page.after_id", after.ID),
+		attribute.Int("page.limit", limit),
+	))
+	defer span.End()
+
+	orders := make([]*entity.Order, 0, limit)
+	err := r.breaker.Do(ctx, func(ctx context.Context) error {
+		return r.readDB(ctx).NewSelect().
+			Model(&orders).
+			Where("(updated_at, id) > (?, ?)", after.UpdatedAt, after.ID).
+			OrderExpr("updated_at ASC, id ASC").
+			Limit(limit).
+			Scan(ctx)
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, err
+	}
+	for _, order := range orders {
+		order.BackfillUpdatedAt()
+	}
+	return orders, nil
+}
@@ -0,0 +1,114 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+func TestWithTxRollsBackOnError(t *testing.T) {
+	db := dbtest.New(t)
+	dbtest.CreateTables(t, db, (*entity.Order)(nil))
+	r := NewRepository(dbtest.Connections(db), nil)
+	ctx := context.Background()
+
+	failed := errors.New("abort")
+	err := r.WithTx(ctx, nil, func(tx *Repository) error {
+		order := &entity.Order{Number: "ORDER-R1", Status: entity.OrderStatusPending, CreatedAt: time.Now().UTC()}
+		if err := tx.Create(ctx, order); err != nil {
+			return err
+		}
+		// Nested WithTx joins the open transaction.
+		return tx.WithTx(ctx, nil, func(*Repository) error { return failed })
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("WithTx error = %v, want %v", err, failed)
+	}
+
+	count, err := db.NewSelect().Model((*entity.Order)(nil)).Count(ctx)
+	if err != nil {
+		t.Fatalf("count orders: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("orders after rollback = %d, want 0", count)
+	}
+}
+
+func TestGetByIDBackfillsNullUpdatedAt(t *testing.T) {
+	db := dbtest.New(t)
+	dbtest.CreateTables(t, db, (*entity.Order)(nil))
+	r := NewRepository(dbtest.Connections(db), nil)
+	ctx := context.Background()
+
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	order := &entity.Order{Number: "ORDER-R2", Status: entity.OrderStatusPending, CreatedAt: created}
+	if _, err := db.NewInsert().Model(order).Exec(ctx); err != nil {
+		t.Fatalf("insert order: %v", err)
+	}
+	nulls, err := db.NewSelect().Model((*entity.Order)(nil)).Where("updated_at IS NULL").Count(ctx)
+	if err != nil || nulls != 1 {
+		t.Fatalf("rows with NULL updated_at = %d, %v; want 1", nulls, err)
+	}
+
+	got, err := r.GetByID(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !got.UpdatedAt.Equal(created) {
+		t.Fatalf("UpdatedAt = %v, want CreatedAt %v", got.UpdatedAt, created)
+	}
+}
+
+func TestListUpdatedSincePagesThroughTiedTimestamps(t *testing.T) {
+	db := dbtest.New(t)
+	dbtest.CreateTables(t, db, (*entity.Order)(nil))
+	r := NewRepository(dbtest.Connections(db), nil)
+	ctx := context.Background()
+
+	// Three orders share one timestamp, so a page of two ends inside the tie.
+	base := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	stamps := []time.Time{base, base.Add(time.Second), base.Add(time.Second), base.Add(time.Second), base.Add(2 * time.Second)}
+	want := make(map[int64]bool, len(stamps))
+	for i, at := range stamps {
+		order := &entity.Order{Number: fmt.Sprintf("ORDER-S%d", i), Status: entity.OrderStatusPending, CreatedAt: at, UpdatedAt: at}
+		if _, err := db.NewInsert().Model(order).Exec(ctx); err != nil {
+			t.Fatalf("insert order: %v", err)
+		}
+		want[order.ID] = true
+	}
+
+	var seen []int64
+	after := UpdatedCursor{UpdatedAt: base.Add(-time.Second)}
+	for page := 0; ; page++ {
+		if page > len(stamps) {
+			t.Fatalf("paging did not finish; seen %v", seen)
+		}
+		orders, err := r.ListUpdatedSince(ctx, after, 2)
+		if err != nil {
+			t.Fatalf("ListUpdatedSince: %v", err)
+		}
+		for _, order := range orders {
+			seen = append(seen, order.ID)
+		}
+		if len(orders) < 2 {
+			break
+		}
+		last := orders[len(orders)-1]
+		after = UpdatedCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
+	}
+
+	if len(seen) != len(want) {
+		t.Fatalf("paged ids %v, want each of the %d orders once", seen, len(want))
+	}
+	for _, id := range seen {
+		if !want[id] {
+			t.Fatalf("paged ids %v, want each of the %d orders once", seen, len(want))
+		}
+		delete(want, id)
+	}
+}
@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Additional-Code/atlas/internal/database"
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+var repoTracer = otel.Tracer("github.com/Additional-Code/atlas/repository/outbox")
+
+// Repository encapsulates access to the outbox_events table. Outbox reads
+// always use the writer so the relay never acts on stale replica state.
+type Repository struct {
+	writer *bun.DB
+	conns  *database.Connections
+}
+
+// NewRepository wires a repository backed by the writer connection.
+func NewRepository(conns *database.Connections) *Repository {
+	return &Repository{writer: conns.Writer, conns: conns}
+}
+
+// RunInTx executes fn inside a writer transaction begun with opts, retrying
+// serialization failures like database.Connections.RunInTx.
+func (r *Repository) RunInTx(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return r.conns.RunInTx(ctx, opts, fn)
+}
+
+// Insert stores a pending event using db, which may be a transaction shared
+// with the domain write. The next sequence for the aggregate is assigned here.
+func (r *Repository) Insert(ctx context.Context, db bun.IDB, event *entity.OutboxEvent) error {
+	if event == nil {
+		return errors.New("nil outbox event")
+	}
+	if event.AggregateID == "" {
+		return errors.New("outbox event aggregate id is required")
+	}
+	if db == nil {
+		db = r.writer
+	}
+	ctx, span := repoTracer.Start(ctx, "OutboxRepository.Insert", trace.WithAttributes(attribute.String("outbox.aggregate_id", event.AggregateID)))
+	defer span.End()
+
+	event.Status = entity.OutboxPending
+	_, err := db.NewInsert().
+		Model(event).
+		Value("sequence", "(SELECT COALESCE(MAX(sequence), 0) + 1 FROM outbox_events WHERE aggregate_id = ?)", event.AggregateID).
+		Returning("id, sequence").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "insert failed")
+	}
+	return err
+}
+
+// ClaimPending locks up to limit pending events with FOR UPDATE SKIP LOCKED,
+// so concurrent relays share work without double-publishing. Only the oldest
+// pending event of each aggregate is eligible, which keeps per-aggregate
+// publishing in sequence order even across relay instances. Events whose
+// next attempt is still in the future are skipped, holding back their
+// aggregate until then.
+func (r *Repository) ClaimPending(ctx context.Context, tx bun.Tx, limit int) ([]*entity.OutboxEvent, error) {
+	ctx, span := repoTracer.Start(ctx, "OutboxRepository.ClaimPending", trace.WithAttributes(attribute.Int("outbox.limit", limit)))
+	defer span.End()
+
+	var events []*entity.OutboxEvent
+	err := tx.NewSelect().
+		Model(&events).
+		Where("e.status = ?", entity.OutboxPending).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("e.next_attempt_at IS NULL").WhereOr("e.next_attempt_at <= ?", time.Now().UTC())
+		}).
+		Where("NOT EXISTS (SELECT 1 FROM outbox_events AS prior WHERE prior.aggregate_id = e.aggregate_id AND prior.status = ? AND prior.sequence < e.sequence)", entity.OutboxPending).
+		OrderExpr("e.id ASC").
+		Limit(limit).
+		For("UPDATE SKIP LOCKED").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("outbox.claimed", len(events)))
+	return events, nil
+}
+
+// MarkSent records a successful publish.
+func (r *Repository) MarkSent(ctx context.Context, db bun.IDB, id int64) error {
+	_, err := db.NewUpdate().
+		Model((*entity.OutboxEvent)(nil)).
+		Set("status = ?", entity.OutboxSent).
+		Set("sent_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// MarkFailed records a failed publish attempt, moving the event to the dead
+// state when dead is true so it no longer blocks its aggregate. Otherwise the
+// event is not claimed again before nextAttempt.
+func (r *Repository) MarkFailed(ctx context.Context, db bun.IDB, id int64, attempts int, lastErr string, dead bool, nextAttempt time.Time) error {
+	status := entity.OutboxPending
+	if dead {
+		status = entity.OutboxDead
+	}
+	_, err := db.NewUpdate().
+		Model((*entity.OutboxEvent)(nil)).
+		Set("status = ?", status).
+		Set("attempts = ?", attempts).
+		Set("last_error = ?", lastErr).
+		Set("next_attempt_at = ?", bun.NullZero(nextAttempt)).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// CountPending returns the number of events awaiting publication.
+func (r *Repository) CountPending(ctx context.Context) (int, error) {
+	return r.writer.NewSelect().
+		Model((*entity.OutboxEvent)(nil)).
+		Where("e.status = ?", entity.OutboxPending).
+		Count(ctx)
+}
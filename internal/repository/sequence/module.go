@@ -0,0 +1,6 @@
+package sequence
+
+import "go.uber.org/fx"
+
+// Module provides the sequence repository to Fx.
+var Module = fx.Provide(NewRepository)
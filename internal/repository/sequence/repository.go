@@ -0,0 +1,83 @@
+package sequence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Additional-Code/atlas/internal/database"
+)
+
+var repoTracer = otel.Tracer("github.com/Additional-Code/atlas/repository/sequence")
+
+// Repository hands out values of named, database-wide sequences. Postgres uses
+// a native "<name>_seq" sequence, which a migration must create; MySQL and
+// SQLite atomically bump a row in the sequences table.
+type Repository struct {
+	writer *bun.DB
+}
+
+// NewRepository wires a repository backed by the writer connection.
+func NewRepository(conns *database.Connections) *Repository {
+	return &Repository{writer: conns.Writer}
+}
+
+// Next returns the next value of the named sequence, starting at 1. Callers
+// must pass a trusted identifier; Postgres embeds it in the sequence name.
+func (r *Repository) Next(ctx context.Context, name string) (int64, error) {
+	ctx, span := repoTracer.Start(ctx, "SequenceRepository.Next", trace.WithAttributes(attribute.String("sequence.name", name)))
+	defer span.End()
+
+	var (
+		value int64
+		err   error
+	)
+	switch r.writer.Dialect().Name() {
+	case dialect.PG:
+		value, err = r.nextPostgres(ctx, name)
+	case dialect.MySQL:
+		value, err = r.nextMySQL(ctx, name)
+	case dialect.SQLite:
+		value, err = r.nextSQLite(ctx, name)
+	default:
+		err = fmt.Errorf("sequences are not supported on %s", r.writer.Dialect().Name())
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "next failed")
+		return 0, err
+	}
+	return value, nil
+}
+
+func (r *Repository) nextPostgres(ctx context.Context, name string) (int64, error) {
+	var value int64
+	err := r.writer.NewRaw("SELECT nextval(?)", name+"_seq").Scan(ctx, &value)
+	return value, err
+}
+
+// nextMySQL relies on LAST_INSERT_ID(expr) to report the bumped value for this
+// connection, so no second query (or transaction) is needed.
+func (r *Repository) nextMySQL(ctx context.Context, name string) (int64, error) {
+	res, err := r.writer.ExecContext(ctx,
+		"INSERT INTO sequences (name, value) VALUES (?, LAST_INSERT_ID(1)) ON DUPLICATE KEY UPDATE value = LAST_INSERT_ID(value + 1)",
+		name)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func (r *Repository) nextSQLite(ctx context.Context, name string) (int64, error) {
+	var value int64
+	err := r.writer.NewRaw(
+		"INSERT INTO sequences (name, value) VALUES (?, 1) ON CONFLICT (name) DO UPDATE SET value = value + 1 RETURNING value",
+		name).Scan(ctx, &value)
+	return value, err
+}
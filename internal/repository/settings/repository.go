@@ -0,0 +1,85 @@
+package settings
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Additional-Code/atlas/internal/database"
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+var repoTracer = otel.Tracer("github.com/Additional-Code/atlas/repository/settings")
+
+// ErrNotFound is returned when a setting is missing.
+var ErrNotFound = errors.New("setting not found")
+
+// Repository encapsulates access to the settings table. Reads use the writer
+// so a toggle is visible as soon as it is set; the service caches them.
+type Repository struct {
+	writer *bun.DB
+}
+
+// NewRepository wires a repository backed by the writer connection.
+func NewRepository(conns *database.Connections) *Repository {
+	return &Repository{writer: conns.Writer}
+}
+
+// Get loads the setting stored under key.
+func (r *Repository) Get(ctx context.Context, key string) (*entity.Setting, error) {
+	ctx, span := repoTracer.Start(ctx, "SettingsRepository.Get", trace.WithAttributes(attribute.String("setting.key", key)))
+	defer span.End()
+
+	setting := new(entity.Setting)
+	err := r.writer.NewSelect().Model(setting).Where("key = ?", key).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		span.SetStatus(codes.Error, "not found")
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, err
+	}
+	return setting, nil
+}
+
+// Set inserts or replaces the value stored under key.
+func (r *Repository) Set(ctx context.Context, key, value string) error {
+	ctx, span := repoTracer.Start(ctx, "SettingsRepository.Set", trace.WithAttributes(attribute.String("setting.key", key)))
+	defer span.End()
+
+	setting := &entity.Setting{Key: key, Value: value, UpdatedAt: time.Now().UTC()}
+	_, err := r.writer.NewInsert().
+		Model(setting).
+		On("CONFLICT (key) DO UPDATE").
+		Set("value = EXCLUDED.value").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "upsert failed")
+	}
+	return err
+}
+
+// List returns every setting ordered by key.
+func (r *Repository) List(ctx context.Context) ([]*entity.Setting, error) {
+	ctx, span := repoTracer.Start(ctx, "SettingsRepository.List")
+	defer span.End()
+
+	var settings []*entity.Setting
+	if err := r.writer.NewSelect().Model(&settings).Order("key ASC").Scan(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "select failed")
+		return nil, err
+	}
+	return settings, nil
+}
@@ -0,0 +1,183 @@
+// Package scaffold generates domain module skeletons that mirror the order
+// module: entity, repository, service, DTO, HTTP handler, and worker handler.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// validName keeps module names usable as Go package names and path segments.
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// templateIdents are identifiers the templates declare or import; a module
+// named after one gets a neutral local variable name instead.
+var templateIdents = map[string]struct{}{
+	"attribute": {}, "b": {}, "bun": {}, "c": {}, "codes": {}, "config": {},
+	"context": {}, "ctx": {}, "data": {}, "database": {}, "dto": {}, "echo": {},
+	"entity": {}, "err": {}, "errorbank": {}, "errors": {}, "fmt": {}, "fx": {},
+	"h": {}, "http": {}, "id": {}, "item": {}, "items": {}, "logger": {}, "v": {},
+	"messaging": {}, "now": {}, "otel": {}, "page": {}, "r": {}, "repo": {},
+	"response": {}, "s": {}, "service": {}, "span": {}, "sql": {}, "strconv": {},
+	"time": {}, "total": {}, "trace": {}, "transport": {}, "worker": {}, "zap": {},
+}
+
+// ErrExists is returned when a file or directory the module needs is already present.
+var ErrExists = errors.New("scaffold target already exists")
+
+// data is what the templates render with.
+type data struct {
+	// Module is the Go module path read from go.mod.
+	Module string
+	// Name is the package name, e.g. product.
+	Name string
+	// Type is the exported type name, e.g. Product.
+	Type string
+	// Var is the local variable name, e.g. product.
+	Var string
+	// Table is the table name and route segment, e.g. products.
+	Table string
+}
+
+// output maps a template onto the file it renders, relative to the repo root.
+type output struct {
+	template string
+	path     string
+}
+
+func outputs(name string) []output {
+	return []output{
+		{"entity.go.tmpl", filepath.Join("internal", "entity", name+".go")},
+		{"repository.go.tmpl", filepath.Join("internal", "repository", name, "repository.go")},
+		{"repository_module.go.tmpl", filepath.Join("internal", "repository", name, "module.go")},
+		{"service.go.tmpl", filepath.Join("internal", "service", name, "service.go")},
+		{"service_module.go.tmpl", filepath.Join("internal", "service", name, "module.go")},
+		{"dto.go.tmpl", filepath.Join("internal", "dto", name+".go")},
+		{"handler.go.tmpl", filepath.Join("internal", "transport", "http", name, "handler.go")},
+		{"handler_module.go.tmpl", filepath.Join("internal", "transport", "http", name, "module.go")},
+		{"worker.go.tmpl", filepath.Join("internal", "worker", name, "handler.go")},
+	}
+}
+
+// Generate writes a module named name under root, which must contain go.mod,
+// and returns the created files relative to root. Nothing is written if any
+// target file or package directory already exists.
+func Generate(root, name string) ([]string, error) {
+	if !validName.MatchString(name) || token.IsKeyword(name) {
+		return nil, fmt.Errorf("module name %q must be lowercase letters and digits, starting with a letter, and not a Go keyword", name)
+	}
+	modulePath, err := readModulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	d := data{
+		Module: modulePath,
+		Name:   name,
+		Type:   strings.ToUpper(name[:1]) + name[1:],
+		Var:    name,
+		Table:  plural(name),
+	}
+	if _, taken := templateIdents[name]; taken {
+		d.Var = "v"
+	}
+
+	files := outputs(name)
+	for _, out := range files {
+		if err := ensureAbsent(root, out.path); err != nil {
+			return nil, err
+		}
+	}
+
+	rendered := make([][]byte, len(files))
+	for i, out := range files {
+		if rendered[i], err = render(out.template, d); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]string, 0, len(files))
+	for i, out := range files {
+		target := filepath.Join(root, out.path)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(target, rendered[i], 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, out.path)
+	}
+	return created, nil
+}
+
+// ensureAbsent rejects a target file that exists, or whose package directory
+// exists for per-module packages (those named after the module).
+func ensureAbsent(root, path string) error {
+	check := path
+	if dir := filepath.Dir(path); filepath.Base(dir) != "entity" && filepath.Base(dir) != "dto" {
+		check = dir
+	}
+	if _, err := os.Stat(filepath.Join(root, check)); err == nil {
+		return fmt.Errorf("%w: %s", ErrExists, check)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func render(name string, d data) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, name, d); err != nil {
+		return nil, fmt.Errorf("render %s: %w", name, err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w", name, err)
+	}
+	return src, nil
+}
+
+func readModulePath(goMod string) (string, error) {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return "", fmt.Errorf("read go.mod (run from the repository root): %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(path), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("%s has no module directive", goMod)
+}
+
+// plural forms the table name with the common English suffix rules.
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
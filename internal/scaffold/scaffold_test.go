@@ -0,0 +1,87 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"errors"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateProductCompiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/Additional-Code/atlas\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	created, err := Generate(root, "product")
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(created) != len(outputs("product")) {
+		t.Fatalf("created %v, want every template rendered", created)
+	}
+
+	// Each file's package clause must match its directory, except the shared
+	// entity and dto packages.
+	repoRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replace := make(map[string]string, len(created))
+	pkgs := make(map[string]struct{})
+	for _, path := range created {
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, path), nil, parser.PackageClauseOnly)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		dir := filepath.Dir(path)
+		if got, want := file.Name.Name, filepath.Base(dir); got != want {
+			t.Errorf("%s declares package %s, want %s", path, got, want)
+		}
+		replace[filepath.Join(repoRoot, path)] = filepath.Join(root, path)
+		pkgs["./"+filepath.ToSlash(dir)] = struct{}{}
+	}
+
+	// Overlay the generated files onto this repository so they compile
+	// against the real packages without touching the tree.
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	overlay, err := json.Marshal(map[string]any{"Replace": replace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayPath := filepath.Join(t.TempDir(), "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		t.Fatalf("write overlay: %v", err)
+	}
+	args := []string{"build", "-overlay", overlayPath}
+	for pkg := range pkgs {
+		args = append(args, pkg)
+	}
+	cmd := exec.Command(goBin, args...)
+	cmd.Dir = repoRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated module does not compile: %v\n%s", err, out)
+	}
+}
+
+func TestGenerateRefusesExistingModule(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	if _, err := Generate(root, "product"); err != nil {
+		t.Fatalf("first Generate: %v", err)
+	}
+	if _, err := Generate(root, "product"); !errors.Is(err, ErrExists) {
+		t.Fatalf("second Generate error = %v, want ErrExists", err)
+	}
+	if _, err := Generate(root, "func"); err == nil {
+		t.Fatal("Generate accepted a Go keyword as the module name")
+	}
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// The schedule is a pure function of the current time, so fixed instants
+// stand in for a clock.
+func TestCronNext(t *testing.T) {
+	// Thursday 15 January 2026, 10:07:30 UTC.
+	now := time.Date(2026, 1, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 15, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2026, 1, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2026, 1, 16, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)},
+		{"0 12 1,20 * 6", time.Date(2026, 1, 17, 12, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron: %v", err)
+			}
+			if got := schedule.next(now); !got.Equal(tt.want) {
+				t.Fatalf("next(%s) = %s, want %s", now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronNeverFiringHasNoNext(t *testing.T) {
+	schedule, err := parseCron("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("parseCron: %v", err)
+	}
+	if got := schedule.next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Fatalf("next = %s, want zero for 31 February", got)
+	}
+}
+
+func TestCronEntryDelayWaitsForNextMatch(t *testing.T) {
+	job, err := newEntry(Job{Name: "report", Cron: "0 * * * *", Run: func(context.Context) error { return nil }})
+	if err != nil {
+		t.Fatalf("newEntry: %v", err)
+	}
+	now := time.Date(2026, 1, 15, 10, 59, 45, 0, time.UTC)
+	for _, first := range []bool{true, false} {
+		if got := job.delay(now, first); got != 15*time.Second {
+			t.Fatalf("delay(first=%v) = %s, want 15s until 11:00", first, got)
+		}
+	}
+}
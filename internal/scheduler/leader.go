@@ -0,0 +1,103 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// elector keeps this instance's claim on the leader lease. Without a shared
+// locker every instance considers itself leader, which suits single-replica
+// setups on the memory or noop cache.
+type elector struct {
+	locker cache.Locker
+	key    string
+	owner  string
+	ttl    time.Duration
+	logger *zap.Logger
+
+	leader atomic.Bool
+}
+
+func newElector(store cache.Store, cfg config.Scheduler, logger *zap.Logger) *elector {
+	e := &elector{key: cfg.LeaderKey, ttl: cfg.LeaderTTL, owner: ownerID(), logger: logger}
+	locker, ok := cache.AsLocker(store)
+	if !ok {
+		logger.Warn("cache store cannot hold locks; singleton jobs run on every instance")
+		e.leader.Store(true)
+
+		return e
+	}
+	e.locker = locker
+	return e
+}
+
+// ownerID names this process in the lease so only it can renew or release it.
+func ownerID() string {
+	host, _ := os.Hostname()
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return host + "-" + hex.EncodeToString(suffix)
+}
+
+// IsLeader reports whether this instance currently holds the lease.
+func (e *elector) IsLeader() bool {
+	return e.leader.Load()
+}
+
+// run claims or renews the lease every third of its TTL until ctx ends, so a
+// renewal can fail twice before the lease lapses.
+func (e *elector) run(ctx context.Context) {
+	if e.locker == nil {
+		return
+	}
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *elector) campaign(ctx context.Context) {
+	held, err := e.locker.TryLock(ctx, e.key, e.owner, e.ttl)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// Step down: another instance may take over once our lease lapses.
+		e.logger.Warn("scheduler leader election failed", zap.Error(err))
+		held = false
+	}
+	if was := e.leader.Swap(held); was != held {
+		e.logger.Info("scheduler leadership changed", zap.Bool("leader", held), zap.String("owner", e.owner))
+	}
+}
+
+// resign releases the lease so another instance can take over without
+// waiting for it to expire.
+func (e *elector) resign(ctx context.Context) {
+	if e.locker == nil || !e.leader.Swap(false) {
+		return
+	}
+	if err := e.locker.Unlock(ctx, e.key, e.owner); err != nil {
+		e.logger.Warn("scheduler leadership release failed; it lapses after the lease TTL", zap.Error(err))
+
+		return
+	}
+	e.logger.Info("scheduler leadership released", zap.String("owner", e.owner))
+}
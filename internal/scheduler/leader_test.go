@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// leaseStore is a cache shared between schedulers that grants leases the
+// way the redis store does.
+type leaseStore struct {
+	cache.Store
+	mu     sync.Mutex
+	owner  string
+	expiry time.Time
+}
+
+func (s *leaseStore) TryLock(_ context.Context, _, owner string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner == "" || s.owner == owner || time.Now().After(s.expiry) {
+		s.owner, s.expiry = owner, time.Now().Add(ttl)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (s *leaseStore) Unlock(_ context.Context, _, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owner == owner {
+		s.owner = ""
+	}
+	return nil
+}
+
+func TestSingletonJobRunsOnOneScheduler(t *testing.T) {
+	store := &leaseStore{}
+	var cfg config.Config
+	cfg.Scheduler = config.Scheduler{LeaderKey: "scheduler:leader", LeaderTTL: 300 * time.Millisecond}
+
+	var runs [2]atomic.Int64
+	schedulers := make([]*Scheduler, 2)
+	for i := range schedulers {
+		s, err := New(Params{
+			Jobs: []Job{{Name: "cleanup", Interval: 10 * time.Millisecond, Singleton: true, Run: func(context.Context) error {
+				runs[i].Add(1)
+				return nil
+			}}},
+			Cache:  store,
+			Config: cfg,
+			Logger: zap.NewNop(),
+		})
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		if err := s.start(context.Background()); err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		schedulers[i] = s
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	leader := 0
+	if runs[1].Load() > 0 {
+		leader = 1
+	}
+	follower := 1 - leader
+	if runs[leader].Load() == 0 || runs[follower].Load() != 0 {
+		t.Fatalf("runs = %d and %d, want the job on exactly one scheduler", runs[0].Load(), runs[1].Load())
+	}
+
+	// Stopping the leader resigns the lease, so the other takes over on its
+	// next campaign instead of waiting for the lease to lapse.
+	if err := schedulers[leader].stop(context.Background()); err != nil {
+		t.Fatalf("stop leader: %v", err)
+	}
+	defer schedulers[follower].stop(context.Background())
+	deadline := time.Now().Add(time.Second)
+	for runs[follower].Load() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("follower never took over the singleton job")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,284 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+const meterName = "github.com/Additional-Code/atlas/scheduler"
+
+// Group is the Fx value group the scheduler collects jobs from.
+const Group = `group:"scheduler.jobs"`
+
+// jitterFraction spreads each wait by up to this share of the interval so
+// replicas started together do not hit shared dependencies in lockstep.
+const jitterFraction = 0.1
+
+// Job is a named periodic task contributed by a module. It runs every Interval
+// or, when Cron is set instead, at the times the cron expression matches. A
+// zero Job (nil Run) is ignored, so a module can opt out at runtime, e.g. when
+// it is disabled.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Cron is a five-field expression or descriptor (e.g. "*/15 * * * *",
+	// "@daily") evaluated in the process's local time zone.
+	Cron string
+	Run  func(ctx context.Context) error
+	// Singleton restricts the job to the instance holding the scheduler's
+	// leader lease, so it runs once per interval across all replicas.
+	Singleton bool
+}
+
+// AsJob annotates a constructor returning Job so it joins the scheduler.jobs
+// group, e.g. fx.Provide(scheduler.AsJob(newRelayJob)).
+func AsJob(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(Group))
+}
+
+// Module runs the registered jobs for the lifetime of the application.
+var Module = fx.Module("scheduler",
+	fx.Provide(New),
+	fx.Invoke(func(lc fx.Lifecycle, s *Scheduler) {
+		lc.Append(fx.Hook{
+			OnStart: s.start,
+			OnStop:  s.stop,
+		})
+	}),
+)
+
+// Params collects the registered jobs via Fx.
+type Params struct {
+	fx.In
+
+	Jobs   []Job `group:"scheduler.jobs"`
+	Cache  cache.Store
+	Config config.Config
+	Logger *zap.Logger
+}
+
+// Scheduler runs each job on its own interval. A job never overlaps with
+// itself: the next wait starts once the previous run returns.
+type Scheduler struct {
+	jobs   []entry
+	logger *zap.Logger
+	// elector is nil unless a singleton job is registered.
+	elector *elector
+
+	runs     metric.Int64Counter
+	duration metric.Float64Histogram
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New validates the registered jobs and their metrics.
+func New(p Params) (*Scheduler, error) {
+	s := &Scheduler{logger: p.Logger}
+	seen := make(map[string]struct{}, len(p.Jobs))
+	for _, job := range p.Jobs {
+		if job.Run == nil {
+			continue
+		}
+		if _, ok := seen[job.Name]; ok {
+			return nil, fmt.Errorf("scheduler job %q registered twice", job.Name)
+		}
+		seen[job.Name] = struct{}{}
+		e, err := newEntry(job)
+		if err != nil {
+			return nil, err
+		}
+		s.jobs = append(s.jobs, e)
+		if job.Singleton && s.elector == nil {
+			s.elector = newElector(p.Cache, p.Config.Scheduler, p.Logger)
+		}
+	}
+
+	meter := otel.Meter(meterName)
+	var err error
+	if s.runs, err = meter.Int64Counter("scheduler.runs", metric.WithDescription("Scheduled job runs by outcome")); err != nil {
+		return nil, err
+	}
+	s.duration, err = meter.Float64Histogram("scheduler.duration",
+		metric.WithDescription("Scheduled job run latency"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) start(context.Context) error {
+	if len(s.jobs) == 0 {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	if s.elector != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.elector.run(runCtx)
+		}()
+	}
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(runCtx, job)
+		}()
+		s.logger.Info("scheduled job registered",
+			zap.String("job", job.Name),
+			zap.Duration("interval", job.Interval),
+			zap.String("cron", job.Cron),
+			zap.Bool("singleton", job.Singleton),
+		)
+	}
+
+	return nil
+}
+
+// stop cancels every job and waits for in-flight runs within ctx, then hands
+// leadership off so singleton jobs resume elsewhere right away.
+func (s *Scheduler) stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.logger.Warn("scheduler stop timed out; jobs still running")
+
+		return ctx.Err()
+	case <-done:
+		if s.elector != nil {
+			s.elector.resign(ctx)
+		}
+		s.logger.Info("scheduler stopped")
+
+		return nil
+	}
+}
+
+// entry is a validated Job with its parsed cron schedule, if any.
+type entry struct {
+	Job
+	cron *cronSchedule
+}
+
+func newEntry(job Job) (entry, error) {
+	if job.Cron == "" {
+		if job.Interval <= 0 {
+			return entry{}, fmt.Errorf("scheduler job %q needs a positive interval or a cron expression", job.Name)
+		}
+		return entry{Job: job}, nil
+	}
+	if job.Interval != 0 {
+		return entry{}, fmt.Errorf("scheduler job %q sets both an interval and a cron expression", job.Name)
+	}
+	schedule, err := parseCron(job.Cron)
+	if err != nil {
+		return entry{}, fmt.Errorf("scheduler job %q: %w", job.Name, err)
+	}
+	if schedule.next(time.Now()).IsZero() {
+		return entry{}, fmt.Errorf("scheduler job %q: cron expression %q never fires", job.Name, job.Cron)
+	}
+	return entry{Job: job, cron: schedule}, nil
+}
+
+// delay is the wait before the job's next run. Interval jobs wait a jittered
+// fraction of the interval before the first run so restarts do not stampede,
+// then a jittered interval; cron jobs wait for the next matching minute.
+func (e entry) delay(now time.Time, first bool) time.Duration {
+	if e.cron != nil {
+		return e.cron.next(now).Sub(now)
+	}
+	if first {
+		return jitter(e.Interval)
+	}
+	return e.Interval + jitter(e.Interval)
+}
+
+// loop runs the job each time its delay elapses. The next delay is measured
+// from when the previous run returns, so runs never overlap.
+func (s *Scheduler) loop(ctx context.Context, job entry) {
+	timer := time.NewTimer(job.delay(time.Now(), true))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		s.run(ctx, job.Job)
+		timer.Reset(job.delay(time.Now(), false))
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	if job.Singleton && !s.elector.IsLeader() {
+		s.logger.Debug("skipping singleton job; not the leader", zap.String("job", job.Name))
+
+		return
+	}
+
+	start := time.Now()
+	err := job.Run(ctx)
+	elapsed := time.Since(start)
+
+	outcome := "success"
+	if err != nil {
+		outcome = "failure"
+		if ctx.Err() == nil {
+			s.logger.Error("scheduled job failed",
+				zap.String("job", job.Name),
+				zap.Duration("duration", elapsed),
+				zap.Error(err),
+			)
+		}
+	} else {
+		s.logger.Debug("scheduled job finished",
+			zap.String("job", job.Name),
+			zap.Duration("duration", elapsed),
+		)
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("job", job.Name),
+		attribute.String("outcome", outcome),
+	)
+	s.runs.Add(ctx, 1, attrs)
+	s.duration.Record(ctx, float64(elapsed)/float64(time.Millisecond), attrs)
+}
+
+func jitter(interval time.Duration) time.Duration {
+	spread := int64(float64(interval) * jitterFraction)
+	if spread <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(spread))
+}
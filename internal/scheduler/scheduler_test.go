@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func newTestScheduler(t *testing.T, jobs ...Job) *Scheduler {
+	t.Helper()
+	s, err := New(Params{Jobs: jobs, Logger: zap.NewNop()})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+func TestSchedulerRunsJobOnIntervalUntilStopped(t *testing.T) {
+	var runs atomic.Int64
+	s := newTestScheduler(t, Job{Name: "tick", Interval: 20 * time.Millisecond, Run: func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	if err := s.start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("runs = %d after 1s, want at least 3 at a 20ms interval", runs.Load())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := s.stop(context.Background()); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	stopped := runs.Load()
+	time.Sleep(60 * time.Millisecond)
+	if got := runs.Load(); got != stopped {
+		t.Fatalf("runs went from %d to %d after stop", stopped, got)
+	}
+}
+
+func TestSchedulerStopCancelsRunningJob(t *testing.T) {
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	s := newTestScheduler(t, Job{Name: "long", Interval: time.Millisecond, Run: func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		cancelled.Store(true)
+		return ctx.Err()
+	}})
+	if err := s.start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.stop(ctx); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if !cancelled.Load() {
+		t.Fatal("stop returned before the running job observed cancellation")
+	}
+}
+
+func TestNewRejectsInvalidJobs(t *testing.T) {
+	run := func(context.Context) error { return nil }
+	for name, jobs := range map[string][]Job{
+		"no schedule":   {{Name: "a", Run: run}},
+		"both":          {{Name: "a", Interval: time.Second, Cron: "* * * * *", Run: run}},
+		"duplicate":     {{Name: "a", Interval: time.Second, Run: run}, {Name: "a", Interval: time.Second, Run: run}},
+		"bad cron expr": {{Name: "a", Cron: "61 * * * *", Run: run}},
+	} {
+		if _, err := New(Params{Jobs: jobs, Logger: zap.NewNop()}); err == nil {
+			t.Errorf("%s: New accepted invalid jobs", name)
+		}
+	}
+}
@@ -7,32 +7,66 @@ import (
 	"github.com/uptrace/bun"
 	"go.uber.org/zap"
 
+	"github.com/Additional-Code/atlas/internal/config"
 	"github.com/Additional-Code/atlas/internal/database"
 	"github.com/Additional-Code/atlas/internal/entity"
 )
 
 // Seeder performs database seeding for local/dev setups.
 type Seeder struct {
-	db     *bun.DB
+	db *bun.DB
+	// check answers pre-insert existence checks: the writer, or the reader
+	// with DB_SEED_CHECK_REPLICA.
+	check  *bun.DB
 	logger *zap.Logger
 }
 
-// New constructs a Seeder backed by the primary database connection.
-func New(conns *database.Connections, logger *zap.Logger) *Seeder {
-	return &Seeder{db: conns.Writer, logger: logger}
+// New constructs a Seeder that inserts through the primary database
+// connection.
+func New(cfg config.Config, conns *database.Connections, logger *zap.Logger) *Seeder {
+	check := conns.Writer
+	if cfg.Database.SeedCheckReplica {
+		check = conns.Reader
+	}
+	return &Seeder{db: conns.Writer, check: check, logger: logger}
 }
 
-// Orders seeds example orders if they are missing.
+// Orders seeds example orders if they are missing. Orders the existence check
+// finds are skipped; the rest are inserted in one statement that still ignores
+// number conflicts, since a lagging replica may miss recently seeded rows.
 func (s *Seeder) Orders(ctx context.Context) error {
 	now := time.Now().UTC()
 	samples := []entity.Order{
-		{Number: "ORDER-1000", Status: "pending", CreatedAt: now, UpdatedAt: now},
-		{Number: "ORDER-1001", Status: "processing", CreatedAt: now, UpdatedAt: now},
+		{Number: "ORDER-1000", Status: entity.OrderStatusPending, CreatedAt: now, UpdatedAt: now},
+		{Number: "ORDER-1001", Status: entity.OrderStatusProcessing, CreatedAt: now, UpdatedAt: now},
 	}
 
+	numbers := make([]string, len(samples))
+	for i, sample := range samples {
+		numbers[i] = sample.Number
+	}
+	var existing []string
+	err := s.check.NewSelect().
+		Model((*entity.Order)(nil)).
+		Column("number").
+		Where("number IN (?)", bun.In(numbers)).
+		Scan(ctx, &existing)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(existing))
+	for _, number := range existing {
+		seen[number] = struct{}{}
+	}
+
+	missing := make([]entity.Order, 0, len(samples))
 	for _, sample := range samples {
-		order := sample
-		_, err := s.db.NewInsert().Model(&order).
+		if _, ok := seen[sample.Number]; !ok {
+			missing = append(missing, sample)
+		}
+	}
+	if len(missing) > 0 {
+		_, err := s.db.NewInsert().Model(&missing).
 			On("CONFLICT (number) DO NOTHING").
 			Exec(ctx)
 		if err != nil {
@@ -40,7 +74,7 @@ func (s *Seeder) Orders(ctx context.Context) error {
 		}
 	}
 
-	s.logger.Info("seeded orders", zap.Int("count", len(samples)))
+	s.logger.Info("seeded orders", zap.Int("count", len(missing)), zap.Int("skipped", len(samples)-len(missing)))
 
 	return nil
 }
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/health"
+)
+
+// healthServer answers grpc.health.v1 from the same dependency checks as the
+// HTTP /ready endpoint. The overall ("") status is refreshed on every Check,
+// so Watch streams see changes as probes run.
+type healthServer struct {
+	*grpchealth.Server
+	readiness *health.Readiness
+}
+
+func (s *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	if req.GetService() == "" {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if s.readiness.Check(ctx).Ready() {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		// Ignored once Shutdown has run, so a stopping server stays NOT_SERVING.
+		s.SetServingStatus("", status)
+	}
+	return s.Server.Check(ctx, req)
+}
+
+// registerServices adds the health service and, with GRPC_ENABLE_REFLECTION,
+// server reflection. It is invoked after Run, so its stop hook runs first and
+// reports NOT_SERVING before the server drains.
+func registerServices(lc fx.Lifecycle, cfg config.Config, server *grpc.Server, readiness *health.Readiness, logger *zap.Logger) {
+	hs := &healthServer{Server: grpchealth.NewServer(), readiness: readiness}
+	healthpb.RegisterHealthServer(server, hs)
+
+	if cfg.GRPC.EnableReflection {
+		reflection.Register(server)
+		logger.Info("gRPC server reflection enabled")
+	}
+
+	lc.Append(fx.Hook{
+		OnStop: func(context.Context) error {
+			hs.Shutdown()
+			return nil
+		},
+	})
+}
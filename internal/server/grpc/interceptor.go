@@ -0,0 +1,158 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// panicked is returned in place of a panicking handler's result. It carries
+// the internal kind, so the call is logged at that kind's level.
+func panicked() error {
+	return errorbank.ToGRPCStatus(errorbank.Internal("internal server error")).Err()
+}
+
+// unaryInterceptor logs every unary call and recovers handler panics as
+// codes.Internal. A handler may return an AppError directly; it is converted
+// with errorbank.ToGRPCStatus, and failures are logged with their errorbank
+// kind and gRPC code at the level configured for the kind (ERROR_LOG_LEVELS).
+func unaryInterceptor(logger *zap.Logger, obs config.Observability) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx = extractTraceContext(ctx)
+		start := time.Now()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.Error("grpc handler panicked",
+					zap.String("method", info.FullMethod),
+					zap.Any("panic", recovered),
+					zap.ByteString("stack", debug.Stack()),
+					traceField(ctx),
+				)
+				resp, err = nil, panicked()
+			}
+			logCall(logger, obs, ctx, "grpc unary call finished", info.FullMethod, time.Since(start), err)
+		}()
+
+		resp, err = handler(ctx, req)
+		var appErr *errorbank.AppError
+		if errors.As(err, &appErr) {
+			err = errorbank.ToGRPCStatus(appErr).Err()
+		}
+		return resp, err
+	}
+}
+
+// streamInterceptor logs every streaming call and recovers handler panics as
+// codes.Internal.
+func streamInterceptor(logger *zap.Logger, obs config.Observability) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := ss.Context()
+		start := time.Now()
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.Error("grpc handler panicked",
+					zap.String("method", info.FullMethod),
+					zap.Any("panic", recovered),
+					zap.ByteString("stack", debug.Stack()),
+					traceField(ctx),
+				)
+				err = panicked()
+			}
+			logCall(logger, obs, ctx, "grpc stream call finished", info.FullMethod, time.Since(start), err)
+		}()
+
+		err = handler(srv, ss)
+		var appErr *errorbank.AppError
+		if errors.As(err, &appErr) {
+			err = errorbank.ToGRPCStatus(appErr).Err()
+		}
+		return err
+	}
+}
+
+// logCall writes the outcome of a call. Errors carrying an atlas ErrorInfo
+// are logged at their kind's level; any other error at warn.
+func logCall(logger *zap.Logger, obs config.Observability, ctx context.Context, msg, method string, duration time.Duration, err error) {
+	fields := []zap.Field{zap.String("method", method), zap.Duration("duration", duration), traceField(ctx)}
+	if err == nil {
+		logger.Info(msg, fields...)
+		return
+	}
+
+	st := status.Convert(err)
+	level := zapcore.WarnLevel
+	if kind, ok := statusKind(st); ok {
+		level = obs.ErrorLogLevel(kind)
+		fields = append(fields, zap.String("kind", string(kind)))
+	}
+	if entry := logger.Check(level, msg); entry != nil {
+		entry.Write(append(fields, zap.String("code", st.Code().String()), zap.Error(err))...)
+	}
+}
+
+// statusKind recovers the errorbank kind from the ErrorInfo detail that
+// errorbank.ToGRPCStatus attaches.
+func statusKind(st *status.Status) (errorbank.Kind, bool) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetDomain() == errorbank.GRPCErrorDomain {
+			return errorbank.Kind(strings.ToLower(info.GetReason())), true
+		}
+	}
+	return "", false
+}
+
+// traceField returns the active trace ID, or a no-op field without a span.
+func traceField(ctx context.Context) zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return zap.Skip()
+	}
+	return zap.String("trace_id", sc.TraceID().String())
+}
+
+// extractTraceContext continues a caller's trace from the incoming metadata
+// so handler spans join it and the call's log line carries its trace ID.
+func extractTraceContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+}
+
+// metadataCarrier adapts gRPC metadata, whose keys are lower-case, to the
+// OpenTelemetry propagation carrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+func TestLogCallLogsAtKindLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	obs := config.Observability{ErrorLogLevels: map[errorbank.Kind]zapcore.Level{
+		errorbank.KindNotFound: zapcore.InfoLevel,
+	}}
+	logger := zap.New(core)
+
+	for _, appErr := range []*errorbank.AppError{errorbank.NotFound("order not found"), errorbank.Internal("database unavailable")} {
+		logCall(logger, obs, context.Background(), "grpc call", "/atlas.v1.OrderService/Get", 0, errorbank.ToGRPCStatus(appErr).Err())
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+	if entries[0].Level != zapcore.InfoLevel || entries[0].ContextMap()["kind"] != string(errorbank.KindNotFound) {
+		t.Errorf("not_found logged at %s with %v, want info with its kind", entries[0].Level, entries[0].ContextMap())
+	}
+	if entries[1].Level != zapcore.ErrorLevel || entries[1].ContextMap()["kind"] != string(errorbank.KindInternal) {
+		t.Errorf("internal logged at %s with %v, want error with its kind", entries[1].Level, entries[1].ContextMap())
+	}
+}
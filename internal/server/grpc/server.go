@@ -2,13 +2,16 @@ package grpc
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
-	"time"
+	"os"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/Additional-Code/atlas/internal/config"
 )
@@ -17,42 +20,67 @@ import (
 var Module = fx.Module("grpc_server",
 	fx.Provide(NewServer),
 	fx.Invoke(Run),
+	fx.Invoke(registerServices),
 )
 
-// NewServer builds a gRPC server with basic unary/stream logging interceptors.
-func NewServer(logger *zap.Logger) *grpc.Server {
-	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		start := time.Now()
-		resp, err := handler(ctx, req)
-		duration := time.Since(start)
+// NewServer builds a gRPC server whose interceptors log each call with its
+// errorbank kind, gRPC code, and trace ID, and turn handler panics into
+// codes.Internal.
+// With GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE set it serves TLS, and with
+// GRPC_CLIENT_CA_FILE also mutual TLS; an unreadable or invalid file fails
+// construction. Plaintext otherwise.
+func NewServer(cfg config.Config, logger *zap.Logger) (*grpc.Server, error) {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptor(logger, cfg.Observability)),
+		grpc.ChainStreamInterceptor(streamInterceptor(logger, cfg.Observability)),
+	}
+	if cfg.GRPC.TLS.Enabled() {
+		tlsConfig, err := serverTLSConfig(cfg.GRPC.TLS)
 		if err != nil {
-			logger.Warn("grpc unary call finished", zap.String("method", info.FullMethod), zap.Duration("duration", duration), zap.Error(err))
-		} else {
-			logger.Info("grpc unary call finished", zap.String("method", info.FullMethod), zap.Duration("duration", duration))
+			return nil, err
 		}
-		return resp, err
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	return grpc.NewServer(opts...), nil
+}
+
+func serverTLSConfig(cfg config.GRPCTLS) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load gRPC TLS certificate (GRPC_TLS_CERT_FILE=%s, GRPC_TLS_KEY_FILE=%s): %w", cfg.CertFile, cfg.KeyFile, err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
 	}
 
-	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		start := time.Now()
-		err := handler(srv, ss)
-		duration := time.Since(start)
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
 		if err != nil {
-			logger.Warn("grpc stream call finished", zap.String("method", info.FullMethod), zap.Duration("duration", duration), zap.Error(err))
-		} else {
-			logger.Info("grpc stream call finished", zap.String("method", info.FullMethod), zap.Duration("duration", duration))
+			return nil, fmt.Errorf("read gRPC client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("gRPC client CA file %s contains no valid certificates", cfg.ClientCAFile)
 		}
-		return err
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
 	}
 
-	return grpc.NewServer(
-		grpc.ChainUnaryInterceptor(unary),
-		grpc.ChainStreamInterceptor(stream),
-	)
+	return tlsConfig, nil
 }
 
 // Run binds the gRPC server to the configured host/port and manages lifecycle.
+// When gRPC is disabled the server and its services stay in the graph but no
+// port is bound.
 func Run(lc fx.Lifecycle, cfg config.Config, server *grpc.Server, logger *zap.Logger) {
+	if !cfg.GRPC.Enabled {
+		logger.Info("grpc server disabled; not binding a listener")
+
+		return
+	}
+
 	addr := fmt.Sprintf("%s:%d", cfg.GRPC.Host, cfg.GRPC.Port)
 	var listener net.Listener
 
@@ -63,7 +91,11 @@ func Run(lc fx.Lifecycle, cfg config.Config, server *grpc.Server, logger *zap.Lo
 				return fmt.Errorf("listen grpc: %w", err)
 			}
 			listener = ln
-			logger.Info("starting gRPC server", zap.String("addr", addr))
+			logger.Info("starting gRPC server",
+				zap.String("addr", addr),
+				zap.Bool("tls", cfg.GRPC.TLS.Enabled()),
+				zap.Bool("mtls", cfg.GRPC.TLS.Enabled() && cfg.GRPC.TLS.ClientCAFile != ""),
+			)
 			go func() {
 				if err := server.Serve(listener); err != nil {
 					logger.Fatal("grpc server failed", zap.Error(err))
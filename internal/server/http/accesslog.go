@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	echo "github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// accessLogMiddleware logs one info line per request with its method, path,
+// status, and latency. The request-scoped logger adds the request ID. Routes
+// listed in skip are not logged.
+func accessLogMiddleware(base *zap.Logger, skip ...string) echo.MiddlewareFunc {
+	skipped := make(map[string]struct{}, len(skip))
+	for _, path := range skip {
+		skipped[path] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if _, ok := skipped[c.Path()]; ok {
+				return err
+			}
+
+			req := c.Request()
+			logger.FromContext(req.Context(), base).Info("http request",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.String("route", c.Path()),
+				zap.Int("status", responseStatus(c, err)),
+				zap.Duration("latency", time.Since(start)),
+			)
+			return err
+		}
+	}
+}
+
+// responseStatus reports the status the client receives. An error returned
+// past this point is rendered later by the error handler, so its status is
+// derived from the error rather than the not-yet-written response.
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	var appErr *errorbank.AppError
+	if errors.As(err, &appErr) {
+		return appErr.StatusCode()
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	echo "github.com/labstack/echo/v4"
+
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// jsonBinder decodes JSON bodies strictly: a body must hold exactly one JSON
+// value, and with disallowUnknown set, unknown fields are rejected. Other
+// content types fall through to Echo's default binder.
+type jsonBinder struct {
+	echo.DefaultBinder
+	disallowUnknown bool
+}
+
+func (b *jsonBinder) Bind(i any, c echo.Context) error {
+	req := c.Request()
+	if req.ContentLength == 0 || !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(req.Body)
+	if b.disallowUnknown {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(i); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errorbank.BadRequest("request body is empty")
+		}
+		return errorbank.BadRequest("malformed JSON body", errorbank.WithDetail("reason", err.Error()), errorbank.WithCause(err))
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errorbank.BadRequest("request body must contain a single JSON value")
+	}
+	return nil
+}
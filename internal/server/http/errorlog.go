@@ -0,0 +1,40 @@
+package http
+
+import (
+	echo "github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// errorLogMiddleware logs the AppError a handler rendered through the response
+// builder at the level configured for its kind (ERROR_LOG_LEVELS), so client
+// errors can stay quiet while server errors are always visible.
+func errorLogMiddleware(base *zap.Logger, obs config.Observability) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if appErr := response.RenderedError(c); appErr != nil {
+				logAppError(logger.FromContext(c.Request().Context(), base), obs, c, appErr)
+			}
+			return err
+		}
+	}
+}
+
+func logAppError(log *zap.Logger, obs config.Observability, c echo.Context, appErr *errorbank.AppError) {
+	entry := log.Check(obs.ErrorLogLevel(appErr.Kind()), "http request failed")
+	if entry == nil {
+		return
+	}
+	entry.Write(
+		zap.String("kind", string(appErr.Kind())),
+		zap.Int("status", appErr.StatusCode()),
+		zap.String("method", c.Request().Method),
+		zap.String("route", c.Path()),
+		zap.Error(appErr),
+	)
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+func TestErrorLogMiddlewareLogsAtKindLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	obs := config.Observability{ErrorLogLevels: map[errorbank.Kind]zapcore.Level{
+		errorbank.KindNotFound: zapcore.InfoLevel,
+	}}
+
+	e := echo.New()
+	e.Use(errorLogMiddleware(zap.New(core), obs))
+	e.GET("/orders/:id", func(c echo.Context) error {
+		return response.New(c).WithError(errorbank.NotFound("order not found")).Build()
+	})
+	e.GET("/boom", func(c echo.Context) error {
+		return response.New(c).WithError(errorbank.Internal("database unavailable")).Build()
+	})
+	e.GET("/ok", func(c echo.Context) error {
+		return response.New(c).WithData("fine").Build()
+	})
+
+	for _, path := range []string{"/orders/1", "/boom", "/ok"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want one per failed request", len(entries))
+	}
+	want := []struct {
+		level  zapcore.Level
+		kind   errorbank.Kind
+		status int64
+		route  string
+	}{
+		{zapcore.InfoLevel, errorbank.KindNotFound, http.StatusNotFound, "/orders/:id"},
+		{zapcore.ErrorLevel, errorbank.KindInternal, http.StatusInternalServerError, "/boom"},
+	}
+	for i, w := range want {
+		entry := entries[i]
+		fields := entry.ContextMap()
+		if entry.Level != w.level {
+			t.Errorf("%s logged at %s, want %s", w.kind, entry.Level, w.level)
+		}
+		if fields["kind"] != string(w.kind) || fields["status"] != w.status || fields["route"] != w.route {
+			t.Errorf("%s fields = %v, want kind, status %d and route %s", w.kind, fields, w.status, w.route)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"strconv"
+
+	echo "github.com/labstack/echo/v4"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// limiterRetryAfter is the Retry-After hint, in seconds, sent with shed requests.
+const limiterRetryAfter = 1
+
+// concurrencyLimiter caps in-flight requests at limit, rejecting the excess
+// with 429 instead of queueing it. Requests whose route is in exempt (health
+// and metrics) always pass so probes keep working under load.
+func concurrencyLimiter(limit int, exempt ...string) echo.MiddlewareFunc {
+	sem := semaphore.NewWeighted(int64(limit))
+	skip := make(map[string]struct{}, len(exempt))
+	for _, path := range exempt {
+		skip[path] = struct{}{}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, ok := skip[c.Path()]; ok {
+				return next(c)
+			}
+			if !sem.TryAcquire(1) {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(limiterRetryAfter))
+				return response.New(c).WithError(errorbank.TooManyRequests("server is at capacity; retry shortly")).Build()
+			}
+			defer sem.Release(1)
+			return next(c)
+		}
+	}
+}
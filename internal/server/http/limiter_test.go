@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestConcurrencyLimiterShedsExcessRequests(t *testing.T) {
+	e := echo.New()
+	e.Use(concurrencyLimiter(2, "/health"))
+	entered, release := make(chan struct{}), make(chan struct{})
+	e.GET("/slow", func(c echo.Context) error {
+		entered <- struct{}{}
+		<-release
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fast", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/health", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serve("/slow").Code
+		}()
+		<-entered
+	}
+
+	shed := serve("/fast")
+	if shed.Code != http.StatusTooManyRequests {
+		t.Fatalf("request over the limit = %d, want 429", shed.Code)
+	}
+	if shed.Header().Get("Retry-After") != "1" {
+		t.Fatalf("Retry-After = %q, want 1", shed.Header().Get("Retry-After"))
+	}
+	if code := serve("/health").Code; code != http.StatusOK {
+		t.Fatalf("exempt route at capacity = %d, want 200", code)
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		if code != http.StatusOK {
+			t.Fatalf("request within the limit = %d, want 200", code)
+		}
+	}
+	if code := serve("/fast").Code; code != http.StatusOK {
+		t.Fatalf("request after capacity freed = %d, want 200", code)
+	}
+}
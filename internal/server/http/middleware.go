@@ -0,0 +1,43 @@
+package http
+
+import (
+	"sort"
+
+	echo "github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+)
+
+// MiddlewareGroup is the Fx value group NewEcho collects middleware from.
+const MiddlewareGroup = `group:"http.middleware"`
+
+// Middleware is an Echo middleware contributed by a feature module. Lower
+// priorities wrap higher ones, so they see the request first and the response
+// last. Ties are broken by Name to keep ordering deterministic.
+type Middleware struct {
+	Name     string
+	Priority int
+	Handler  echo.MiddlewareFunc
+}
+
+// AsMiddleware annotates a constructor returning Middleware so it joins the
+// middleware group, e.g. fx.Provide(http.AsMiddleware(NewAuthMiddleware)).
+func AsMiddleware(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(MiddlewareGroup))
+}
+
+// sortMiddleware orders registrations by priority, then name, dropping empties.
+func sortMiddleware(registrations []Middleware) []Middleware {
+	ordered := make([]Middleware, 0, len(registrations))
+	for _, m := range registrations {
+		if m.Handler != nil {
+			ordered = append(ordered, m)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+	return ordered
+}
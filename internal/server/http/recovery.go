@@ -0,0 +1,58 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	echo "github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/logger"
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// requestIDMiddleware assigns each request an ID (reusing an inbound
+// X-Request-ID), echoes it in the response header, and stores a logger tagged
+// with it on the request context for logger.FromContext.
+func requestIDMiddleware(base *zap.Logger) echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, id string) {
+			req := c.Request()
+			scoped := base.With(zap.String("request_id", id))
+			c.SetRequest(req.WithContext(logger.WithContext(req.Context(), scoped)))
+		},
+	})
+}
+
+// recoverMiddleware turns a handler panic into a 500 rendered through the
+// response builder, logging the panic value and stack.
+func recoverMiddleware(base *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				logger.FromContext(c.Request().Context(), base).Error("http handler panicked",
+					zap.Any("panic", recovered),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				appErr := errorbank.Internal("internal server error", errorbank.WithCause(fmt.Errorf("panic: %v", recovered)))
+				if c.Response().Committed {
+					// Headers are already out; all that is left is to surface the error.
+					err = appErr
+					return
+				}
+				err = response.New(c).WithError(appErr).Build()
+			}()
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"fmt"
+
+	echo "github.com/labstack/echo/v4"
+)
+
+// checkRouteConflict reports an error when a request for method and path
+// would already be served by a registered route. Echo silently replaces
+// duplicate registrations, and a static path also shadows a parameterised
+// one (e.g. /orders/export over /orders/:id), so both are caught here.
+func checkRouteConflict(e *echo.Echo, method, path string) error {
+	probe := echo.New()
+	for _, route := range e.Routes() {
+		probe.Add(route.Method, route.Path, func(echo.Context) error { return nil })
+	}
+
+	c := probe.NewContext(nil, nil)
+	probe.Router().Find(method, path, c)
+	// Find also resolves paths registered only for other methods (a 405), which
+	// a new route would not shadow.
+	for _, route := range e.Routes() {
+		if route.Method == method && route.Path == c.Path() {
+			return fmt.Errorf("route %s %s collides with registered route %s", method, path, route.Path)
+		}
+	}
+	return nil
+}
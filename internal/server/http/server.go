@@ -2,71 +2,208 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 
 	echo "github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/health"
+	"github.com/Additional-Code/atlas/internal/logger"
 	"github.com/Additional-Code/atlas/internal/observability"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
 )
 
 // Module exposes the HTTP server lifecycle to Fx.
 var Module = fx.Module("http_server",
-	fx.Provide(NewEcho),
-	fx.Invoke(Run),
+	fx.Provide(NewEcho, NewRouter),
+	fx.Invoke(registerSystemRoutes, Run),
 )
 
-// NewEcho configures the Echo router with basic middleware.
-func NewEcho(cfg config.Config, obs *observability.Manager, logger *zap.Logger) *echo.Echo {
+// EchoParams collects dependencies for NewEcho via Fx.
+type EchoParams struct {
+	fx.In
+
+	Config        config.Config
+	Observability *observability.Manager
+	Logger        *zap.Logger
+	Middleware    []Middleware `group:"http.middleware"`
+}
+
+// NewEcho configures the Echo instance with struct-tag validation, request IDs,
+// access logging, panic recovery, per-kind error logging, CORS (when origins
+// are configured), tracing, the concurrency limit, and any middleware
+// contributed through the http.middleware group, applied in priority order.
+func NewEcho(p EchoParams) *echo.Echo {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
+	e.Validator = newStructValidator()
+	e.Binder = &jsonBinder{disallowUnknown: p.Config.HTTP.StrictJSON}
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
-		logger.Error("http request failed", zap.Error(err))
+		log := logger.FromContext(c.Request().Context(), p.Logger)
+		var appErr *errorbank.AppError
+		if errors.As(err, &appErr) {
+			errorbank.RecordRendered(c.Request().Context(), appErr)
+			logAppError(log, p.Config.Observability, c, appErr)
+		} else {
+			log.Error("http request failed", zap.Error(err))
+		}
 		c.Echo().DefaultHTTPErrorHandler(err, c)
 	}
 
-	if obs != nil && obs.TracingEnabled() {
-		e.Use(otelecho.Middleware(cfg.Observability.ServiceName))
+	// The request ID wraps everything so recovery and later middleware log it.
+	e.Use(requestIDMiddleware(p.Logger))
+	// Outside recovery, so a recovered panic is logged with its 500.
+	if access := p.Config.HTTP.AccessLog; access.Enabled {
+		skip := make([]string, 0, len(access.SkipPaths))
+		for _, path := range access.SkipPaths {
+			skip = append(skip, p.Config.HTTP.BasePath+path)
+		}
+		e.Use(accessLogMiddleware(p.Logger, skip...))
+	}
+	if p.Config.HTTP.EnableRecovery {
+		e.Use(recoverMiddleware(p.Logger))
+	}
+	// Inside recovery, which logs a panic itself before rendering its 500.
+	e.Use(errorLogMiddleware(p.Logger, p.Config.Observability))
+	if cors := p.Config.HTTP.CORS; len(cors.AllowedOrigins) > 0 {
+		e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:     cors.AllowedOrigins,
+			AllowMethods:     cors.AllowedMethods,
+			AllowCredentials: cors.AllowCredentials,
+			ExposeHeaders:    []string{echo.HeaderXRequestID},
+		}))
+	}
+
+	if obs := p.Observability; obs != nil && obs.TracingEnabled() {
+		e.Use(otelecho.Middleware(p.Config.Observability.ServiceName))
 	}
 
-	e.GET("/health", func(c echo.Context) error {
-		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
-	})
+	if limit := p.Config.HTTP.MaxConcurrent; limit > 0 {
+		base := p.Config.HTTP.BasePath
+		e.Use(concurrencyLimiter(limit, base+"/health", base+"/ready", base+p.Config.Observability.PrometheusPath))
+	}
 
-	if obs != nil && obs.MetricsEnabled() && obs.MetricsHandler() != nil {
-		e.GET(cfg.Observability.PrometheusPath, echo.WrapHandler(obs.MetricsHandler()))
+	for _, m := range sortMiddleware(p.Middleware) {
+		p.Logger.Debug("registering http middleware", zap.String("name", m.Name), zap.Int("priority", m.Priority))
+		e.Use(m.Handler)
 	}
 
 	return e
 }
 
-// Run starts the HTTP server and ties it to the Fx lifecycle.
-func Run(lc fx.Lifecycle, cfg config.Config, e *echo.Echo, logger *zap.Logger) {
+// NewRouter returns the group every route is mounted on, rooted at HTTP_BASE_PATH.
+func NewRouter(cfg config.Config, e *echo.Echo) *echo.Group {
+	return e.Group(cfg.HTTP.BasePath)
+}
+
+// registerSystemRoutes mounts health, readiness, and metrics once every API
+// route is registered, refusing to start if any would shadow one (or be
+// shadowed). /health only reports the process is up; /ready also checks its
+// dependencies and answers 503 with the per-dependency status when one is down.
+func registerSystemRoutes(lc fx.Lifecycle, cfg config.Config, e *echo.Echo, router *echo.Group, obs *observability.Manager, readiness *health.Readiness) {
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			if err := checkRouteConflict(e, http.MethodGet, cfg.HTTP.BasePath+"/health"); err != nil {
+				return err
+			}
+			router.GET("/health", func(c echo.Context) error {
+				return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+			})
+
+			if err := checkRouteConflict(e, http.MethodGet, cfg.HTTP.BasePath+"/ready"); err != nil {
+				return err
+			}
+			router.GET("/ready", func(c echo.Context) error {
+				report := readiness.Check(c.Request().Context())
+				if !report.Ready() {
+					return c.JSON(http.StatusServiceUnavailable, report)
+				}
+				return c.JSON(http.StatusOK, report)
+			})
+
+			if obs != nil && obs.MetricsEnabled() && obs.MetricsHandler() != nil {
+				path := cfg.Observability.PrometheusPath
+				if err := checkRouteConflict(e, http.MethodGet, cfg.HTTP.BasePath+path); err != nil {
+					return fmt.Errorf("OBS_PROMETHEUS_PATH: %w", err)
+				}
+				router.GET(path, echo.WrapHandler(obs.MetricsHandler()))
+			}
+			return nil
+		},
+	})
+}
+
+// Run starts the HTTP server and ties it to the Fx lifecycle. When HTTP is
+// disabled the router stays in the graph but no port is bound. With
+// HTTP_TLS_CERT_FILE and HTTP_TLS_KEY_FILE set it serves HTTPS; an unreadable
+// certificate or key fails startup.
+func Run(lc fx.Lifecycle, cfg config.Config, e *echo.Echo, logger *zap.Logger) error {
+	if !cfg.HTTP.Enabled {
+		logger.Info("http server disabled; not binding a listener")
+
+		return nil
+	}
+
 	addr := fmt.Sprintf("%s:%d", cfg.HTTP.Host, cfg.HTTP.Port)
 
 	server := &http.Server{
 		Addr:    addr,
 		Handler: e,
 	}
+	if cfg.HTTP.TLS.Enabled() {
+		cert, err := tls.LoadX509KeyPair(cfg.HTTP.TLS.CertFile, cfg.HTTP.TLS.KeyFile)
+		if err != nil {
+			return fmt.Errorf("load HTTP TLS certificate (HTTP_TLS_CERT_FILE=%s, HTTP_TLS_KEY_FILE=%s): %w", cfg.HTTP.TLS.CertFile, cfg.HTTP.TLS.KeyFile, err)
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+	}
 
 	lc.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			logger.Info("starting HTTP server", zap.String("addr", addr))
+			logger.Info("starting HTTP server", zap.String("addr", addr), zap.Bool("tls", server.TLSConfig != nil))
 			go func() {
-				if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				var err error
+				if server.TLSConfig != nil {
+					// The certificate is already in TLSConfig.
+					err = server.ListenAndServeTLS("", "")
+				} else {
+					err = server.ListenAndServe()
+				}
+				if err != nil && err != http.ErrServerClosed {
 					logger.Fatal("http server failed", zap.Error(err))
 				}
 			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			logger.Info("stopping HTTP server")
-			return server.Shutdown(ctx)
+			// A shorter deadline on the Fx stop context still wins.
+			ctx, cancel := context.WithTimeout(ctx, cfg.HTTP.ShutdownTimeout)
+			defer cancel()
+
+			logger.Info("stopping HTTP server; draining in-flight requests",
+				zap.Float64("grace_seconds", cfg.HTTP.ShutdownTimeout.Seconds()),
+			)
+			if err := server.Shutdown(ctx); err != nil {
+				logger.Warn("http shutdown timed out; closing remaining connections", zap.Error(err))
+
+				return server.Close()
+			}
+			logger.Info("http server stopped; in-flight requests completed")
+
+			return nil
 		},
 	})
+
+	return nil
 }
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// freePort returns a port that was free a moment ago.
+func freePort(t *testing.T) int {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestRunDisabledBindsNoPort(t *testing.T) {
+	var cfg config.Config
+	cfg.HTTP.Enabled = false
+	cfg.HTTP.Host = "127.0.0.1"
+	cfg.HTTP.Port = freePort(t)
+
+	lc := fxtest.NewLifecycle(t)
+	if err := Run(lc, cfg, echo.New(), zap.NewNop()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(cfg.HTTP.Host, strconv.Itoa(cfg.HTTP.Port)))
+	if err != nil {
+		t.Fatalf("port %d is bound with HTTP disabled: %v", cfg.HTTP.Port, err)
+	}
+	_ = listener.Close()
+}
+
+func TestRunEnabledBindsPort(t *testing.T) {
+	var cfg config.Config
+	cfg.HTTP.Enabled = true
+	cfg.HTTP.Host = "127.0.0.1"
+	cfg.HTTP.Port = freePort(t)
+	cfg.HTTP.ShutdownTimeout = time.Second
+
+	lc := fxtest.NewLifecycle(t)
+	if err := Run(lc, cfg, echo.New(), zap.NewNop()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	addr := net.JoinHostPort(cfg.HTTP.Host, strconv.Itoa(cfg.HTTP.Port))
+	deadline := time.Now().Add(time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("HTTP server never listened on %s: %v", addr, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// structValidator adapts go-playground/validator to echo.Validator so handlers
+// can declare rules with `validate:"..."` struct tags.
+type structValidator struct {
+	validate *validator.Validate
+}
+
+func newStructValidator() *structValidator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	// Report fields by their JSON name so error details match the request body.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+	return &structValidator{validate: v}
+}
+
+// Validate returns validator.ValidationErrors for rule violations; the
+// response builder renders those as an unprocessable_entity error.
+func (s *structValidator) Validate(i any) error {
+	return s.validate.Struct(i)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// WithDefaultTimeout bounds ctx by timeout when it carries no deadline, so
+// deadline-less callers (workers, CLI) cannot hang on a stuck dependency.
+// Existing deadlines are kept untouched, whether shorter or longer.
+func WithDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithDefaultTimeoutAddsDeadline(t *testing.T) {
+	before := time.Now()
+	ctx, cancel := WithDefaultTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("deadline-less context got no deadline")
+	}
+	if deadline.Before(before.Add(time.Second)) || deadline.After(time.Now().Add(time.Second)) {
+		t.Fatalf("deadline %v is not one second out", deadline)
+	}
+}
+
+func TestWithDefaultTimeoutKeepsCallerDeadline(t *testing.T) {
+	parent, parentCancel := context.WithTimeout(context.Background(), time.Hour)
+	defer parentCancel()
+	want, _ := parent.Deadline()
+
+	ctx, cancel := WithDefaultTimeout(parent, time.Second)
+	defer cancel()
+	if got, _ := ctx.Deadline(); !got.Equal(want) {
+		t.Fatalf("deadline = %v, want the caller's %v", got, want)
+	}
+}
+
+func TestWithDefaultTimeoutDisabled(t *testing.T) {
+	ctx, cancel := WithDefaultTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("zero timeout set a deadline")
+	}
+}
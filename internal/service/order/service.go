@@ -10,34 +10,65 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/uptrace/bun"
 
 	"github.com/Additional-Code/atlas/internal/cache"
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/database"
 	"github.com/Additional-Code/atlas/internal/entity"
 	"github.com/Additional-Code/atlas/internal/messaging"
 	repo "github.com/Additional-Code/atlas/internal/repository/order"
+	outboxrepo "github.com/Additional-Code/atlas/internal/repository/outbox"
+	"github.com/Additional-Code/atlas/internal/service"
+	"github.com/Additional-Code/atlas/internal/service/sequence"
 	"github.com/Additional-Code/atlas/pkg/errorbank"
 )
 
 var serviceTracer = otel.Tracer("github.com/Additional-Code/atlas/service/order")
 
+// orderNumberSequence numbers orders created without a client-supplied number.
+const orderNumberSequence = "order_number"
+
+// generatedNumberPrefix marks sequence-assigned numbers, keeping them apart
+// from the seeded and client-supplied ORDER-<n> numbers.
+const generatedNumberPrefix = "ORD-"
+
+// MaxListLimit is the largest page size List will return.
+const MaxListLimit = repo.MaxListLimit
+
+// UpdatedCursor is a position in the order ListUpdatedSince pages through.
+type UpdatedCursor = repo.UpdatedCursor
+
 // Service encapsulates business logic around orders.
 type Service struct {
 	repo      *repo.Repository
+	outbox    *outboxrepo.Repository
 	cache     cache.Store
 	cacheTTL  time.Duration
 	logger    *zap.Logger
 	publisher messaging.Client
+	sequence  *sequence.Service
 	messaging messagingConfig
+	timeout   time.Duration
+	// loads collapses concurrent cache misses for the same key into one repository read.
+	loads singleflight.Group
 }
 
 // messagingConfig contains messaging specific knobs we care about.
 type messagingConfig struct {
 	enabled bool
 	topic   string
+	// eventTopics routes event types to their own topics; others use topic.
+	eventTopics map[string]string
+	// outbox writes events to outbox_events inside the domain transaction
+	// instead of publishing them directly; the outbox relay publishes them.
+	outbox bool
 }
 
 // Params defines dependencies for constructing Service.
@@ -45,121 +76,423 @@ type Params struct {
 	fx.In
 
 	Repository *repo.Repository
+	Outbox     *outboxrepo.Repository
 	Cache      cache.Store
 	Config     config.Config
 	Logger     *zap.Logger
 	Publisher  messaging.Client
+	Sequence   *sequence.Service
 }
 
 // NewService wires a new Service instance.
 func NewService(p Params) *Service {
 	return &Service{
 		repo:      p.Repository,
+		outbox:    p.Outbox,
 		cache:     p.Cache,
 		cacheTTL:  p.Config.Cache.DefaultTTL,
 		logger:    p.Logger,
 		publisher: p.Publisher,
+		sequence:  p.Sequence,
 		messaging: messagingConfig{
-			enabled: p.Config.Messaging.Enabled,
-			topic:   p.Config.Messaging.Kafka.Topic,
+			enabled:     p.Config.Messaging.Enabled,
+			topic:       p.Config.Messaging.Kafka.Topic,
+			eventTopics: p.Config.Messaging.Kafka.EventTopics,
+			outbox:      p.Config.Messaging.Enabled && p.Config.Outbox.Enabled,
 		},
+		timeout: p.Config.Service.OperationTimeout,
 	}
 }
 
 // Get retrieves an order by id, consulting cache when available.
 func (s *Service) Get(ctx context.Context, id int64) (*entity.Order, error) {
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
 	ctx, span := serviceTracer.Start(ctx, "OrderService.Get", trace.WithAttributes(attribute.Int64("order.id", id)))
 	defer span.End()
 
-	if order, err := s.getFromCache(ctx, id); err == nil {
-		return order, nil
-	} else if err != nil && !errors.Is(err, cache.ErrCacheMiss) {
+	payload, err := s.remember(ctx, id)
+	switch {
+	case err == nil:
+	case payload != nil:
+		s.logger.Warn("orders cache write failed", zap.Int64("id", id), zap.Error(err))
+	case isAppError(err):
+		return nil, err
+	case ctx.Err() != nil:
+		return nil, repositoryError("failed to load order", err)
+	default:
 		s.logger.Warn("orders cache read failed", zap.Int64("id", id), zap.Error(err))
 
+		if payload, err = s.loadOrder(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	var order entity.Order
+	if err := json.Unmarshal(payload, &order); err != nil {
+		// A corrupt or old-format entry must not break the order until its
+		// TTL expires: drop it and read the database instead.
+		s.logger.Warn("orders cache entry undecodable; reloading", zap.Int64("id", id), zap.Error(err))
+		s.invalidateCache(ctx, id)
+		if payload, err = s.loadOrder(ctx, id); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &order); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "decode error")
+			return nil, errorbank.Internal("failed to decode order", errorbank.WithCause(err))
+		}
+	}
+	return &order, nil
+}
+
+// List returns a page of orders plus the total count matching status.
+func (s *Service) List(ctx context.Context, offset, limit int, status entity.OrderStatus) ([]*entity.Order, int, error) {
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx, span := serviceTracer.Start(ctx, "OrderService.List", trace.WithAttributes(attribute.String("order.status", string(status))))
+	defer span.End()
+
+	orders, total, err := s.repo.List(ctx, offset, limit, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "repository error")
+		return nil, 0, repositoryError("failed to list orders", err)
+	}
+	return orders, total, nil
+}
+
+// ListUpdatedSince returns up to limit orders positioned after the cursor in
+// (updated_at, id) order, oldest change first.
+func (s *Service) ListUpdatedSince(ctx context.Context, after UpdatedCursor, limit int) ([]*entity.Order, error) {
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx, span := serviceTracer.Start(ctx, "OrderService.ListUpdatedSince", trace.WithAttributes(
+		attribute.String("order.updated_since", after.UpdatedAt.Format(time.RFC3339Nano)),
+		attribute.Int64("order.after_id", after.ID),
+	))
+	defer span.End()
+
+	orders, err := s.repo.ListUpdatedSince(ctx, after, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "repository error")
+		return nil, repositoryError("failed to list updated orders", err)
+	}
+	return orders, nil
+}
+
+// remember reads the order through the cache. Concurrent callers for the same
+// key share a single in-flight lookup, and therefore its result or error. The
+// lookup runs detached from any one caller's cancellation, bounded by the
+// service operation timeout, so a caller that gives up does not fail the
+// others; it just stops waiting.
+func (s *Service) remember(ctx context.Context, id int64) ([]byte, error) {
+	key := s.cacheKey(id)
+	result := s.loads.DoChan(key, func() (any, error) {
+		loadCtx, cancel := service.WithDefaultTimeout(context.WithoutCancel(ctx), s.timeout)
+		defer cancel()
+		return cache.Remember(loadCtx, s.cache, key, s.cacheTTL, func() ([]byte, error) {
+			return s.loadOrder(loadCtx, id)
+		})
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Shared {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("order.load_shared", true))
+		}
+		payload, _ := res.Val.([]byte)
+		return payload, res.Err
 	}
+}
 
+// loadOrder reads an order from the repository and encodes it for caching.
+func (s *Service) loadOrder(ctx context.Context, id int64) ([]byte, error) {
 	order, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repo.ErrNotFound) {
 			return nil, errorbank.NotFound("order not found")
 		}
+		span := trace.SpanFromContext(ctx)
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "repository error")
-		return nil, errorbank.Internal("failed to load order", errorbank.WithCause(err))
+		return nil, repositoryError("failed to load order", err)
+	}
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return nil, errorbank.Internal("failed to encode order", errorbank.WithCause(err))
 	}
+	return payload, nil
+}
 
-	if err := s.storeInCache(ctx, order); err != nil {
-		s.logger.Warn("orders cache write failed", zap.Int64("id", id), zap.Error(err))
+// InvalidStatusError reports status as unprocessable, listing the allowed values.
+func InvalidStatusError(status string) *errorbank.AppError {
+	return errorbank.Unprocessable("invalid order status",
+		errorbank.WithDetail("status", status),
+		errorbank.WithDetail("allowed", entity.OrderStatuses()),
+	)
+}
+
+func validateStatus(status entity.OrderStatus) error {
+	if !status.Valid() {
+		return InvalidStatusError(string(status))
+	}
+	return nil
+}
+
+// repositoryError maps a repository failure onto an AppError, reporting an
+// open database circuit as unavailable so clients back off and retry.
+func repositoryError(message string, err error) *errorbank.AppError {
+	if errors.Is(err, database.ErrCircuitOpen) {
+		return errorbank.Unavailable("database temporarily unavailable", errorbank.WithCause(err))
 	}
+	return errorbank.Internal(message, errorbank.WithCause(err))
+}
 
-	return order, nil
+func isAppError(err error) bool {
+	var appErr *errorbank.AppError
+	return errors.As(err, &appErr)
 }
 
-// Create creates a new order in the database and refreshes cache state.
+// Create creates a new order in the database and refreshes cache state. An
+// order without a number is assigned the next ORD-<n>.
 func (s *Service) Create(ctx context.Context, order *entity.Order) error {
 	if order == nil {
 		return errorbank.BadRequest("order payload is required")
 	}
-	if order.CreatedAt.IsZero() {
-		now := time.Now().UTC()
-		order.CreatedAt = now
-		order.UpdatedAt = now
+	if err := validateStatus(order.Status); err != nil {
+		return err
 	}
-	ctx, span := serviceTracer.Start(ctx, "OrderService.Create", trace.WithAttributes(attribute.String("order.number", order.Number)))
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx, span := serviceTracer.Start(ctx, "OrderService.Create")
 	defer span.End()
 
-	if err := s.repo.Create(ctx, order); err != nil {
+	if order.Number == "" {
+		n, err := s.sequence.Next(ctx, orderNumberSequence)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "sequence error")
+			return err
+		}
+		order.Number = fmt.Sprintf("%s%d", generatedNumberPrefix, n)
+	}
+	span.SetAttributes(attribute.String("order.number", order.Number))
+	if order.CreatedAt.IsZero() {
+		order.CreatedAt = time.Now().UTC()
+	}
+	order.BackfillUpdatedAt()
+
+	created := func() OrderCreatedEvent {
+		return OrderCreatedEvent{
+			Type:      EventOrderCreated,
+			ID:        order.ID,
+			Number:    order.Number,
+			Status:    string(order.Status),
+			CreatedAt: order.CreatedAt,
+		}
+	}
+	// The outbox row commits or rolls back together with the order.
+	err := s.repo.WithTx(ctx, nil, func(tx *repo.Repository) error {
+		if err := tx.Create(ctx, order); err != nil {
+			return err
+		}
+		return s.enqueue(ctx, tx.DB(), order.ID, created())
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "repository error")
-		return errorbank.Internal("failed to create order", errorbank.WithCause(err))
+		return repositoryError("failed to create order", err)
 	}
 
 	if err := s.storeInCache(ctx, order); err != nil {
 		s.logger.Warn("orders cache write failed", zap.Int64("id", order.ID), zap.Error(err))
 	}
 
-	s.publishOrderCreated(ctx, order)
+	s.publish(ctx, order.ID, created())
 	return nil
 }
 
-func (s *Service) publishOrderCreated(ctx context.Context, order *entity.Order) {
-	if !s.messaging.enabled || s.publisher == nil {
-		return
+// Update persists changes to an existing order and invalidates its cache entry.
+// Every update emits order.updated; one that changes the status additionally
+// emits order.status_changed carrying the previous and new status.
+func (s *Service) Update(ctx context.Context, order *entity.Order) error {
+	if order == nil {
+		return errorbank.BadRequest("order payload is required")
+	}
+	if order.ID <= 0 {
+		return errorbank.BadRequest("order id is required")
+	}
+	if err := validateStatus(order.Status); err != nil {
+		return err
+	}
+	order.UpdatedAt = time.Now().UTC()
+
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx, span := serviceTracer.Start(ctx, "OrderService.Update", trace.WithAttributes(attribute.Int64("order.id", order.ID)))
+	defer span.End()
+
+	var events []event
+	err := s.repo.WithTx(ctx, nil, func(tx *repo.Repository) error {
+		current, err := tx.GetByIDForUpdate(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Update(ctx, order); err != nil {
+			return err
+		}
+		events = updateEvents(current.Status, order)
+		for _, event := range events {
+			if err := s.enqueue(ctx, tx.DB(), order.ID, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return errorbank.NotFound("order not found")
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "repository error")
+		return repositoryError("failed to update order", err)
+	}
+
+	s.invalidateCache(ctx, order.ID)
+
+	for _, event := range events {
+		s.publish(ctx, order.ID, event)
 	}
-	event := OrderCreatedEvent{
+	return nil
+}
+
+// updateEvents returns the events for an update of order whose status was
+// previously from: order.updated, then order.status_changed if it changed.
+func updateEvents(from entity.OrderStatus, order *entity.Order) []event {
+	events := []event{OrderUpdatedEvent{
+		Type:      EventOrderUpdated,
 		ID:        order.ID,
 		Number:    order.Number,
-		Status:    order.Status,
-		CreatedAt: order.CreatedAt,
+		Status:    string(order.Status),
+		UpdatedAt: order.UpdatedAt,
+	}}
+	if from != order.Status {
+		events = append(events, OrderStatusChangedEvent{
+			Type:      EventOrderStatusChanged,
+			ID:        order.ID,
+			Number:    order.Number,
+			From:      string(from),
+			To:        string(order.Status),
+			ChangedAt: order.UpdatedAt,
+		})
+	}
+	return events
+}
+
+// Delete removes an order and invalidates its cache entry.
+func (s *Service) Delete(ctx context.Context, id int64) error {
+	ctx, cancel := service.WithDefaultTimeout(ctx, s.timeout)
+	defer cancel()
+	ctx, span := serviceTracer.Start(ctx, "OrderService.Delete", trace.WithAttributes(attribute.Int64("order.id", id)))
+	defer span.End()
+
+	deleted := OrderDeletedEvent{
+		Type:      EventOrderDeleted,
+		ID:        id,
+		DeletedAt: time.Now().UTC(),
+	}
+	err := s.repo.WithTx(ctx, nil, func(tx *repo.Repository) error {
+		if err := tx.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.enqueue(ctx, tx.DB(), id, deleted)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrNotFound) {
+			return errorbank.NotFound("order not found")
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "repository error")
+		return repositoryError("failed to delete order", err)
+	}
+
+	s.invalidateCache(ctx, id)
+
+	s.publish(ctx, id, deleted)
+	return nil
+}
+
+// enqueue writes event to the outbox through db (the order transaction) when
+// the outbox is enabled. The current trace context is stored with it so the
+// relayed message continues this request's trace.
+func (s *Service) enqueue(ctx context.Context, db bun.IDB, id int64, event event) error {
+	if !s.messaging.outbox {
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal order event: %w", err)
+	}
+	headers := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+
+	key := eventKey(id)
+	return s.outbox.Insert(ctx, db, &entity.OutboxEvent{
+		AggregateID: key,
+		Topic:       s.messaging.topicFor(event),
+		Key:         []byte(key),
+		Payload:     payload,
+		Headers:     headers,
+	})
+}
+
+// publish sends event straight to its topic; with the outbox enabled the
+// relay does that instead.
+func (s *Service) publish(ctx context.Context, id int64, event event) {
+	if !s.messaging.enabled || s.messaging.outbox || s.publisher == nil {
+		return
 	}
 	payload, err := json.Marshal(event)
 	if err != nil {
-		s.logger.Error("marshal order created", zap.Error(err))
+		s.logger.Error("marshal order event", zap.Error(err))
 		return
 	}
-	if err := s.publisher.Publish(ctx, []byte(fmt.Sprintf("order-%d", order.ID)), payload); err != nil {
-		s.logger.Error("publish order created", zap.Error(err))
+	if err := s.publisher.PublishTo(ctx, s.messaging.topicFor(event), []byte(eventKey(id)), payload); err != nil {
+		s.logger.Error("publish order event", zap.Error(err))
+
+	}
+}
 
+// topicFor returns the topic configured for event's type (KAFKA_EVENT_TOPICS),
+// falling back to the default topic.
+func (m messagingConfig) topicFor(event event) string {
+	if topic, ok := m.eventTopics[event.eventType()]; ok {
+		return topic
 	}
+	return m.topic
+}
+
+// eventKey is the message key and outbox aggregate of an order's events, so
+// each order's events stay ordered.
+func eventKey(id int64) string {
+	return fmt.Sprintf("order-%d", id)
 }
 
 func (s *Service) cacheKey(id int64) string {
 	return fmt.Sprintf("orders:%d", id)
 }
 
-func (s *Service) getFromCache(ctx context.Context, id int64) (*entity.Order, error) {
+func (s *Service) invalidateCache(ctx context.Context, id int64) {
 	if s.cache == nil {
-		return nil, cache.ErrCacheMiss
-	}
-	bytes, err := s.cache.Get(ctx, s.cacheKey(id))
-	if err != nil {
-		return nil, err
+		return
 	}
-	var order entity.Order
-	if err := json.Unmarshal(bytes, &order); err != nil {
-		return nil, err
+	if err := s.cache.Delete(ctx, s.cacheKey(id)); err != nil {
+		s.logger.Warn("orders cache invalidate failed", zap.Int64("id", id), zap.Error(err))
 	}
-	return &order, nil
 }
 
 func (s *Service) storeInCache(ctx context.Context, order *entity.Order) error {
@@ -173,10 +506,56 @@ func (s *Service) storeInCache(ctx context.Context, order *entity.Order) error {
 	return s.cache.Set(ctx, s.cacheKey(order.ID), bytes, s.cacheTTL)
 }
 
+// Event types carried in the "type" field of order events.
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderUpdated       = "order.updated"
+	EventOrderStatusChanged = "order.status_changed"
+	EventOrderDeleted       = "order.deleted"
+)
+
+// event is an order event; its type selects the topic it is published to.
+type event interface {
+	eventType() string
+}
+
 // OrderCreatedEvent is emitted when a new order is persisted.
 type OrderCreatedEvent struct {
+	Type      string    `json:"type"`
 	ID        int64     `json:"id"`
 	Number    string    `json:"number"`
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 }
+
+// OrderUpdatedEvent is emitted when an existing order changes.
+type OrderUpdatedEvent struct {
+	Type      string    `json:"type"`
+	ID        int64     `json:"id"`
+	Number    string    `json:"number"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrderStatusChangedEvent is emitted, after OrderUpdatedEvent, when an update
+// moves an order to a different status.
+type OrderStatusChangedEvent struct {
+	Type      string    `json:"type"`
+	ID        int64     `json:"id"`
+	Number    string    `json:"number"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// OrderDeletedEvent is emitted when an order is removed.
+type OrderDeletedEvent struct {
+	Type      string    `json:"type"`
+	ID        int64     `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func (e OrderCreatedEvent) eventType() string       { return e.Type }
+func (e OrderUpdatedEvent) eventType() string       { return e.Type }
+func (e OrderStatusChangedEvent) eventType() string { return e.Type }
+func (e OrderDeletedEvent) eventType() string       { return e.Type }
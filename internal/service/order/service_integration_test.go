@@ -0,0 +1,36 @@
+//go:build integration
+
+package order
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+)
+
+func TestUpdatePublishesStatusChangedOnlyOnChange(t *testing.T) {
+	svc, _, order := newTestServiceOn(t, dbtest.Postgres(t), cache.NewLRU(16, time.Minute))
+	svc.messaging = messagingConfig{enabled: true, topic: "orders"}
+	ctx := context.Background()
+
+	update := func(status entity.OrderStatus) map[string]string {
+		t.Helper()
+		publisher := &recordingPublisher{published: map[string]string{}}
+		svc.publisher = publisher
+		if err := svc.Update(ctx, &entity.Order{ID: order.ID, Number: order.Number, Status: status}); err != nil {
+			t.Fatalf("Update to %s: %v", status, err)
+		}
+		return publisher.published
+	}
+
+	if published := update(entity.OrderStatusPending); len(published) != 1 || published[EventOrderUpdated] == "" {
+		t.Fatalf("same-status update published %v, want only %s", published, EventOrderUpdated)
+	}
+	if published := update(entity.OrderStatusProcessing); published[EventOrderUpdated] == "" || published[EventOrderStatusChanged] == "" {
+		t.Fatalf("status-changing update published %v, want %s and %s", published, EventOrderUpdated, EventOrderStatusChanged)
+	}
+}
@@ -0,0 +1,279 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	repo "github.com/Additional-Code/atlas/internal/repository/order"
+	seqrepo "github.com/Additional-Code/atlas/internal/repository/sequence"
+	"github.com/Additional-Code/atlas/internal/service/sequence"
+)
+
+// orderReads counts SELECTs on orders and delays each one so concurrent
+// callers overlap.
+type orderReads struct {
+	count atomic.Int64
+	delay time.Duration
+}
+
+func (h *orderReads) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if strings.HasPrefix(event.Query, "SELECT") && strings.Contains(event.Query, `"orders"`) {
+		h.count.Add(1)
+		time.Sleep(h.delay)
+	}
+	return ctx
+}
+
+func (h *orderReads) AfterQuery(context.Context, *bun.QueryEvent) {}
+
+func newTestService(t *testing.T, store cache.Store) (*Service, *orderReads, *entity.Order) {
+	t.Helper()
+	return newTestServiceOn(t, dbtest.New(t), store)
+}
+
+// newTestServiceOn builds a Service over db with one pending order inserted.
+func newTestServiceOn(t *testing.T, db *bun.DB, store cache.Store) (*Service, *orderReads, *entity.Order) {
+	t.Helper()
+	dbtest.CreateTables(t, db, (*entity.Order)(nil))
+	if _, err := db.ExecContext(t.Context(), "CREATE TABLE sequences (name VARCHAR(64) PRIMARY KEY, value BIGINT NOT NULL)"); err != nil {
+		t.Fatalf("create sequences: %v", err)
+	}
+
+	order := &entity.Order{Number: "ORDER-T1", Status: entity.OrderStatusPending, CreatedAt: time.Now().UTC()}
+	if _, err := db.NewInsert().Model(order).Exec(t.Context()); err != nil {
+		t.Fatalf("insert order: %v", err)
+	}
+	reads := &orderReads{delay: 50 * time.Millisecond}
+	db.AddQueryHook(reads)
+
+	var cfg config.Config
+	cfg.Cache.DefaultTTL = time.Minute
+	cfg.Service.OperationTimeout = 5 * time.Second
+	seq, err := sequence.NewService(sequence.Params{Repository: seqrepo.NewRepository(dbtest.Connections(db)), Config: cfg})
+	if err != nil {
+		t.Fatalf("sequence service: %v", err)
+	}
+	svc := NewService(Params{
+		Repository: repo.NewRepository(dbtest.Connections(db), nil),
+		Cache:      store,
+		Config:     cfg,
+		Logger:     zap.NewNop(),
+		Sequence:   seq,
+	})
+	return svc, reads, order
+}
+
+func TestGetConcurrentCallersHitRepositoryOnce(t *testing.T) {
+	svc, reads, order := newTestService(t, cache.NewLRU(16, time.Minute))
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := svc.Get(context.Background(), order.ID)
+			if err == nil && got.Number != order.Number {
+				t.Errorf("Get returned number %q, want %q", got.Number, order.Number)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+	if got := reads.count.Load(); got != 1 {
+		t.Fatalf("repository reads = %d, want 1", got)
+	}
+}
+
+func TestGetCancelledCallerDoesNotFailSharedLoad(t *testing.T) {
+	svc, _, order := newTestService(t, cache.NewLRU(16, time.Minute))
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := svc.Get(first, order.ID)
+		firstErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := svc.Get(context.Background(), order.ID)
+		second <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; err == nil {
+		t.Fatal("cancelled caller got no error")
+	}
+	if err := <-second; err != nil {
+		t.Fatalf("waiting caller failed with the first caller's cancellation: %v", err)
+	}
+}
+
+func TestGetReloadsUndecodableCacheEntry(t *testing.T) {
+	store := cache.NewLRU(16, time.Minute)
+	svc, _, order := newTestService(t, store)
+	if err := store.Set(context.Background(), svc.cacheKey(order.ID), []byte("{not json"), time.Minute); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	got, err := svc.Get(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("Get with corrupt cache entry: %v", err)
+	}
+	if got.Number != order.Number {
+		t.Fatalf("Get returned number %q, want %q", got.Number, order.Number)
+	}
+	if _, err := store.Get(context.Background(), svc.cacheKey(order.ID)); err == nil {
+		t.Fatal("corrupt cache entry was not removed")
+	}
+}
+
+// recordingPublisher captures the topic and event type of every publish.
+type recordingPublisher struct {
+	messaging.Client
+	mu        sync.Mutex
+	published map[string]string
+}
+
+func (p *recordingPublisher) PublishTo(_ context.Context, topic string, _ []byte, value []byte) error {
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(value, &event); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published[event.Type] = topic
+	return nil
+}
+
+func TestPublishRoutesEventTypesToTheirTopics(t *testing.T) {
+	svc, _, order := newTestService(t, cache.NewLRU(16, time.Minute))
+	publisher := &recordingPublisher{published: map[string]string{}}
+	svc.publisher = publisher
+	svc.messaging = messagingConfig{
+		enabled: true,
+		topic:   "orders",
+		eventTopics: map[string]string{
+			EventOrderCreated:       "orders.created",
+			EventOrderStatusChanged: "orders.status",
+		},
+	}
+
+	ctx := context.Background()
+	if err := svc.Create(ctx, &entity.Order{Number: "ORDER-T2", Status: entity.OrderStatusPending}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	// Update locks the row with FOR UPDATE, which SQLite lacks, so its events
+	// are published directly.
+	svc.publish(ctx, order.ID, OrderUpdatedEvent{Type: EventOrderUpdated, ID: order.ID})
+	svc.publish(ctx, order.ID, OrderStatusChangedEvent{Type: EventOrderStatusChanged, ID: order.ID})
+	if err := svc.Delete(ctx, order.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	want := map[string]string{
+		EventOrderCreated:       "orders.created",
+		EventOrderUpdated:       "orders",
+		EventOrderStatusChanged: "orders.status",
+		EventOrderDeleted:       "orders",
+	}
+	for eventType, topic := range want {
+		if got := publisher.published[eventType]; got != topic {
+			t.Errorf("%s published to %q, want %q", eventType, got, topic)
+		}
+	}
+}
+
+func TestCreateAssignsPrefixedSequenceNumber(t *testing.T) {
+	svc, _, _ := newTestService(t, cache.NewLRU(16, time.Minute))
+
+	for _, want := range []string{"ORD-1", "ORD-2"} {
+		order := &entity.Order{Status: entity.OrderStatusPending}
+		if err := svc.Create(context.Background(), order); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if order.Number != want {
+			t.Fatalf("assigned number %q, want %q", order.Number, want)
+		}
+	}
+}
+
+func TestUpdateEventsEmitStatusChangedOnlyOnChange(t *testing.T) {
+	order := &entity.Order{ID: 1, Number: "ORD-1", Status: entity.OrderStatusProcessing}
+
+	unchanged := updateEvents(entity.OrderStatusProcessing, order)
+	if len(unchanged) != 1 || unchanged[0].(OrderUpdatedEvent).Type != EventOrderUpdated {
+		t.Fatalf("events for an unchanged status = %+v, want only order.updated", unchanged)
+	}
+
+	changed := updateEvents(entity.OrderStatusPending, order)
+	if len(changed) != 2 {
+		t.Fatalf("events for a status change = %+v, want order.updated and order.status_changed", changed)
+	}
+	statusChanged, ok := changed[1].(OrderStatusChangedEvent)
+	if !ok || statusChanged.From != string(entity.OrderStatusPending) || statusChanged.To != string(entity.OrderStatusProcessing) {
+		t.Fatalf("status event = %+v, want pending -> processing", changed[1])
+	}
+}
+
+// sequenceDeadlines records whether each query on sequences carried a deadline.
+type sequenceDeadlines struct {
+	mu        sync.Mutex
+	deadlines []bool
+}
+
+func (h *sequenceDeadlines) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if strings.Contains(event.Query, "sequences") {
+		_, ok := ctx.Deadline()
+		h.mu.Lock()
+		h.deadlines = append(h.deadlines, ok)
+		h.mu.Unlock()
+	}
+	return ctx
+}
+
+func (h *sequenceDeadlines) AfterQuery(context.Context, *bun.QueryEvent) {}
+
+func TestCreateBoundsNumberAssignmentByOperationTimeout(t *testing.T) {
+	db := dbtest.New(t)
+	svc, _, _ := newTestServiceOn(t, db, cache.NewLRU(16, time.Minute))
+	hook := &sequenceDeadlines{}
+	db.AddQueryHook(hook)
+
+	if err := svc.Create(context.Background(), &entity.Order{Status: entity.OrderStatusPending}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(hook.deadlines) == 0 {
+		t.Fatal("no sequence query observed")
+	}
+	for i, ok := range hook.deadlines {
+		if !ok {
+			t.Fatalf("sequence query %d ran without a deadline", i)
+		}
+	}
+}
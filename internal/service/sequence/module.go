@@ -0,0 +1,6 @@
+package sequence
+
+import "go.uber.org/fx"
+
+// Module provides the sequence service to Fx.
+var Module = fx.Provide(NewService)
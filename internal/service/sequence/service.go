@@ -0,0 +1,61 @@
+package sequence
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"go.uber.org/fx"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	repo "github.com/Additional-Code/atlas/internal/repository/sequence"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// validName keeps sequence names safe to embed in database identifiers.
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,59}$`)
+
+// Service hands out collision-free, monotonically increasing values of named
+// sequences shared by every instance. Values may skip (e.g. after a rolled
+// back insert) but never repeat.
+type Service struct {
+	next func(ctx context.Context, name string) (int64, error)
+}
+
+// Params defines dependencies for constructing Service.
+type Params struct {
+	fx.In
+
+	Repository *repo.Repository
+	Cache      cache.Store
+	Config     config.Config
+}
+
+// NewService selects the SEQUENCE_BACKEND: the database (native sequences on
+// Postgres, a counter table elsewhere) or redis INCR through the cache store.
+func NewService(p Params) (*Service, error) {
+	if p.Config.Sequence.Backend != config.SequenceBackendRedis {
+		return &Service{next: p.Repository.Next}, nil
+	}
+	counter, ok := cache.AsCounter(p.Cache)
+	if !ok {
+		return nil, errors.New("SEQUENCE_BACKEND=redis requires a redis-backed CACHE_DRIVER (redis, or tiered over redis)")
+	}
+	return &Service{next: func(ctx context.Context, name string) (int64, error) {
+		return counter.Incr(ctx, "sequence:"+name)
+	}}, nil
+}
+
+// Next returns the next value of the named sequence, starting at 1. Names are
+// lower-case identifiers such as "order_number".
+func (s *Service) Next(ctx context.Context, name string) (int64, error) {
+	if !validName.MatchString(name) {
+		return 0, errorbank.Internal("invalid sequence name " + name)
+	}
+	value, err := s.next(ctx, name)
+	if err != nil {
+		return 0, errorbank.Internal("failed to allocate sequence value", errorbank.WithCause(err))
+	}
+	return value, nil
+}
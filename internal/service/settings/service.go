@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/entity"
+	repo "github.com/Additional-Code/atlas/internal/repository/settings"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// Well-known setting keys.
+const (
+	// KeyMaintenanceMode holds "true" while the service is in maintenance.
+	KeyMaintenanceMode = "maintenance_mode"
+	// featurePrefix namespaces feature toggles, e.g. "feature.checkout".
+	featurePrefix = "feature."
+)
+
+// Cached payloads start with a marker so absent keys are cached too and a
+// missing toggle does not hit the database on every check.
+const (
+	markerFound   = '1'
+	markerMissing = '0'
+)
+
+// Service reads and writes runtime settings through the cache.
+type Service struct {
+	repo     *repo.Repository
+	cache    cache.Store
+	cacheTTL time.Duration
+	logger   *zap.Logger
+}
+
+// Params defines dependencies for constructing Service.
+type Params struct {
+	fx.In
+
+	Repository *repo.Repository
+	Cache      cache.Store
+	Config     config.Config
+	Logger     *zap.Logger
+}
+
+// NewService wires a new Service instance.
+func NewService(p Params) *Service {
+	return &Service{
+		repo:     p.Repository,
+		cache:    p.Cache,
+		cacheTTL: p.Config.Settings.CacheTTL,
+		logger:   p.Logger,
+	}
+}
+
+// Lookup returns the value stored under key and whether it exists. When the
+// cache cannot be read the database answers, so an outage does not revert
+// every setting to its default.
+func (s *Service) Lookup(ctx context.Context, key string) (string, bool, error) {
+	payload, err := cache.Remember(ctx, s.cache, s.cacheKey(key), s.cacheTTL, func() ([]byte, error) {
+		return s.load(ctx, key)
+	})
+	switch {
+	case err == nil:
+	case payload != nil:
+		s.logger.Warn("settings cache write failed", zap.String("key", key), zap.Error(err))
+	case isAppError(err), ctx.Err() != nil:
+		return "", false, err
+	default:
+		s.logger.Warn("settings cache read failed; reading the database", zap.String("key", key), zap.Error(err))
+
+		if payload, err = s.load(ctx, key); err != nil {
+			return "", false, err
+		}
+	}
+	if len(payload) == 0 || payload[0] != markerFound {
+		return "", false, nil
+	}
+	return string(payload[1:]), true, nil
+}
+
+// load reads key from the repository and encodes it for caching.
+func (s *Service) load(ctx context.Context, key string) ([]byte, error) {
+	setting, err := s.repo.Get(ctx, key)
+	if errors.Is(err, repo.ErrNotFound) {
+		return []byte{markerMissing}, nil
+	}
+	if err != nil {
+		return nil, errorbank.Internal("failed to load setting", errorbank.WithCause(err))
+	}
+	return append([]byte{markerFound}, setting.Value...), nil
+}
+
+func isAppError(err error) bool {
+	var appErr *errorbank.AppError
+	return errors.As(err, &appErr)
+}
+
+// String returns the value under key, or fallback when unset or unreadable.
+func (s *Service) String(ctx context.Context, key, fallback string) string {
+	value, ok, err := s.Lookup(ctx, key)
+	if err != nil {
+		s.logger.Warn("settings read failed; using default", zap.String("key", key), zap.Error(err))
+		return fallback
+	}
+	if !ok {
+		return fallback
+	}
+	return value
+}
+
+// Bool parses the value under key, returning fallback when unset, unreadable,
+// or not a boolean.
+func (s *Service) Bool(ctx context.Context, key string, fallback bool) bool {
+	value, err := strconv.ParseBool(strings.TrimSpace(s.String(ctx, key, strconv.FormatBool(fallback))))
+	if err != nil {
+		s.logger.Warn("setting is not a boolean; using default", zap.String("key", key))
+		return fallback
+	}
+	return value
+}
+
+// Set persists value under key and drops the cached copy.
+func (s *Service) Set(ctx context.Context, key, value string) error {
+	if strings.TrimSpace(key) == "" {
+		return errorbank.BadRequest("setting key is required")
+	}
+	if err := s.repo.Set(ctx, key, value); err != nil {
+		return errorbank.Internal("failed to save setting", errorbank.WithCause(err))
+	}
+	if s.cache != nil {
+		if err := s.cache.Delete(ctx, s.cacheKey(key)); err != nil {
+			s.logger.Warn("settings cache invalidate failed", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// List returns every stored setting, bypassing the cache.
+func (s *Service) List(ctx context.Context) ([]*entity.Setting, error) {
+	settings, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, errorbank.Internal("failed to list settings", errorbank.WithCause(err))
+	}
+	return settings, nil
+}
+
+// MaintenanceMode reports whether maintenance mode is switched on.
+func (s *Service) MaintenanceMode(ctx context.Context) bool {
+	return s.Bool(ctx, KeyMaintenanceMode, false)
+}
+
+// FeatureEnabled reports whether the named feature toggle is on; unset
+// toggles use fallback.
+func (s *Service) FeatureEnabled(ctx context.Context, name string, fallback bool) bool {
+	return s.Bool(ctx, FeatureKey(name), fallback)
+}
+
+// FeatureKey returns the setting key backing the named feature toggle.
+func FeatureKey(name string) string {
+	return featurePrefix + name
+}
+
+func (s *Service) cacheKey(key string) string {
+	return "settings:" + key
+}
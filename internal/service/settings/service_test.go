@@ -0,0 +1,126 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+	repo "github.com/Additional-Code/atlas/internal/repository/settings"
+)
+
+// settingReads counts SELECTs on settings.
+type settingReads struct{ count atomic.Int64 }
+
+func (h *settingReads) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	if strings.HasPrefix(event.Query, "SELECT") && strings.Contains(event.Query, `"settings"`) {
+		h.count.Add(1)
+	}
+	return ctx
+}
+
+func (h *settingReads) AfterQuery(context.Context, *bun.QueryEvent) {}
+
+func newTestService(t *testing.T) (*Service, *settingReads) {
+	t.Helper()
+	return newTestServiceWith(t, cache.NewLRU(16, time.Minute))
+}
+
+// newTestServiceWith builds a Service over a fresh settings table and store.
+func newTestServiceWith(t *testing.T, store cache.Store) (*Service, *settingReads) {
+	t.Helper()
+	db := dbtest.New(t)
+	dbtest.CreateTables(t, db, (*entity.Setting)(nil))
+	reads := &settingReads{}
+	db.AddQueryHook(reads)
+
+	var cfg config.Config
+	cfg.Settings.CacheTTL = time.Minute
+	return NewService(Params{
+		Repository: repo.NewRepository(dbtest.Connections(db)),
+		Cache:      store,
+		Config:     cfg,
+		Logger:     zap.NewNop(),
+	}), reads
+}
+
+func TestSettingPersistsAndIsReadThroughCache(t *testing.T) {
+	svc, reads := newTestService(t)
+	ctx := context.Background()
+
+	if err := svc.Set(ctx, KeyMaintenanceMode, "true"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	for range 3 {
+		if !svc.MaintenanceMode(ctx) {
+			t.Fatal("MaintenanceMode = false after setting it")
+		}
+	}
+	if got := reads.count.Load(); got != 1 {
+		t.Fatalf("repository reads = %d, want 1 with the rest served from cache", got)
+	}
+
+	if err := svc.Set(ctx, KeyMaintenanceMode, "false"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if svc.MaintenanceMode(ctx) {
+		t.Fatal("MaintenanceMode = true after switching it off; cache not invalidated")
+	}
+}
+
+func TestMissingSettingIsCached(t *testing.T) {
+	svc, reads := newTestService(t)
+	ctx := context.Background()
+
+	for range 2 {
+		if !svc.FeatureEnabled(ctx, "checkout", true) {
+			t.Fatal("unset toggle did not use its fallback")
+		}
+	}
+	if got := reads.count.Load(); got != 1 {
+		t.Fatalf("repository reads = %d, want 1 for a cached miss", got)
+	}
+}
+
+// downCache fails every operation like an unreachable Redis.
+type downCache struct{ cache.Store }
+
+func (downCache) Get(context.Context, string) ([]byte, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (downCache) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (downCache) Delete(context.Context, string) error { return errors.New("connection refused") }
+
+func TestSettingsReadDatabaseWhenCacheIsDown(t *testing.T) {
+	svc, reads := newTestServiceWith(t, downCache{})
+	ctx := context.Background()
+
+	if err := svc.Set(ctx, KeyMaintenanceMode, "true"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := svc.Set(ctx, FeatureKey("checkout"), "false"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !svc.MaintenanceMode(ctx) {
+		t.Fatal("MaintenanceMode = false with the cache down, want the stored true")
+	}
+	if svc.FeatureEnabled(ctx, "checkout", true) {
+		t.Fatal("FeatureEnabled = true with the cache down, want the stored false")
+	}
+	if got := reads.count.Load(); got != 2 {
+		t.Fatalf("repository reads = %d, want one per lookup", got)
+	}
+}
@@ -0,0 +1,51 @@
+package transport
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// Validatable is implemented by request DTOs needing checks that struct tags
+// cannot express, such as rules spanning several fields.
+type Validatable interface {
+	Validate() error
+}
+
+// BindAndValidate binds the request into a T, applies the Echo validator's
+// `validate` struct tags, then runs its Validate method when present. Tag
+// violations are returned as validator.ValidationErrors, which the response
+// builder renders as unprocessable_entity; other failures are errorbank errors.
+func BindAndValidate[T any](c echo.Context) (T, error) {
+	var payload T
+	if err := c.Bind(&payload); err != nil {
+		var zero T
+		var appErr *errorbank.AppError
+		if errors.As(err, &appErr) {
+			return zero, appErr
+		}
+		return zero, errorbank.BadRequest("invalid payload", errorbank.WithCause(err))
+	}
+
+	if c.Echo().Validator != nil {
+		if err := c.Validate(&payload); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+
+	if v, ok := any(&payload).(Validatable); ok {
+		if err := v.Validate(); err != nil {
+			var zero T
+			var appErr *errorbank.AppError
+			if errors.As(err, &appErr) {
+				return zero, appErr
+			}
+			return zero, errorbank.BadRequest(err.Error(), errorbank.WithCause(err))
+		}
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,12 @@
+package grpc
+
+import (
+	"go.uber.org/fx"
+
+	ordertransport "github.com/Additional-Code/atlas/internal/transport/grpc/order"
+)
+
+// Module aggregates all gRPC transport handlers.
+var Module = fx.Options(
+	ordertransport.Module,
+)
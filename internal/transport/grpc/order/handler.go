@@ -0,0 +1,118 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Additional-Code/atlas/internal/dto"
+	"github.com/Additional-Code/atlas/internal/entity"
+	service "github.com/Additional-Code/atlas/internal/service/order"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+	ordersv1 "github.com/Additional-Code/atlas/proto/orders/v1"
+)
+
+var grpcTracer = otel.Tracer("github.com/Additional-Code/atlas/transport/grpc/order")
+
+// Handler exposes order endpoints over gRPC.
+type Handler struct {
+	ordersv1.UnimplementedOrderServiceServer
+
+	svc *service.Service
+}
+
+// NewHandler constructs an order Handler.
+func NewHandler(svc *service.Service) *Handler {
+	return &Handler{svc: svc}
+}
+
+// Register adds the order service to the gRPC server.
+func Register(server *grpc.Server, h *Handler) {
+	ordersv1.RegisterOrderServiceServer(server, h)
+}
+
+// GetOrder returns one order.
+func (h *Handler) GetOrder(ctx context.Context, req *ordersv1.GetOrderRequest) (*ordersv1.GetOrderResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, toStatus(errorbank.BadRequest("invalid id"))
+	}
+
+	ctx, span := grpcTracer.Start(ctx, "orders.GetOrder", trace.WithAttributes(attribute.Int64("order.id", req.GetId())))
+	defer span.End()
+
+	order, err := h.svc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &ordersv1.GetOrderResponse{Order: toProto(order)}, nil
+}
+
+// CreateOrder stores a new order.
+func (h *Handler) CreateOrder(ctx context.Context, req *ordersv1.CreateOrderRequest) (*ordersv1.CreateOrderResponse, error) {
+	if err := validateCreate(req); err != nil {
+		return nil, toStatus(err)
+	}
+	orderStatus, err := entity.ParseOrderStatus(req.GetStatus())
+	if err != nil {
+		return nil, toStatus(service.InvalidStatusError(req.GetStatus()))
+	}
+
+	order := &entity.Order{
+		Number: req.GetNumber(),
+		Status: orderStatus,
+	}
+
+	ctx, span := grpcTracer.Start(ctx, "orders.CreateOrder")
+	defer span.End()
+
+	if err := h.svc.Create(ctx, order); err != nil {
+		return nil, toStatus(err)
+	}
+	span.SetAttributes(attribute.String("order.number", order.Number))
+
+	return &ordersv1.CreateOrderResponse{Order: toProto(order)}, nil
+}
+
+// validateCreate applies the same rules as the HTTP CreateOrderRequest tags
+// and fails with the same unprocessable error.
+func validateCreate(req *ordersv1.CreateOrderRequest) error {
+	fields := make(map[string]any)
+	if len(req.GetNumber()) > dto.MaxOrderNumberLen {
+		fields["number"] = fmt.Sprintf("must be at most %d characters", dto.MaxOrderNumberLen)
+	}
+	switch {
+	case req.GetStatus() == "":
+		fields["status"] = "is required"
+	case len(req.GetStatus()) > dto.MaxOrderStatusLen:
+		fields["status"] = fmt.Sprintf("must be at most %d characters", dto.MaxOrderStatusLen)
+	}
+	if len(fields) > 0 {
+		return errorbank.Unprocessable("validation failed", errorbank.WithDetails(fields))
+	}
+	return nil
+}
+
+// toStatus renders err as a gRPC status with the AppError's details attached.
+func toStatus(err error) error {
+	return errorbank.ToGRPCStatus(err).Err()
+}
+
+func toProto(order *entity.Order) *ordersv1.Order {
+	pb := &ordersv1.Order{
+		Id:     order.ID,
+		Number: order.Number,
+		Status: string(order.Status),
+	}
+	if !order.CreatedAt.IsZero() {
+		pb.CreatedAt = timestamppb.New(order.CreatedAt)
+	}
+	if !order.UpdatedAt.IsZero() {
+		pb.UpdatedAt = timestamppb.New(order.UpdatedAt)
+	}
+	return pb
+}
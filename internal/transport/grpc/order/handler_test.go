@@ -0,0 +1,45 @@
+package order
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Additional-Code/atlas/internal/dto"
+	service "github.com/Additional-Code/atlas/internal/service/order"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+	ordersv1 "github.com/Additional-Code/atlas/proto/orders/v1"
+)
+
+func TestCreateOrderInvalidStatusMatchesHTTP(t *testing.T) {
+	h := NewHandler(nil)
+
+	_, err := h.CreateOrder(context.Background(), &ordersv1.CreateOrderRequest{Status: "teleported"})
+
+	want := errorbank.ToGRPCStatus(service.InvalidStatusError("teleported"))
+	got := status.Convert(err)
+	if got.Code() != codes.FailedPrecondition || got.Code() != want.Code() {
+		t.Fatalf("code = %s, want %s", got.Code(), want.Code())
+	}
+	if got.Message() != want.Message() {
+		t.Fatalf("message = %q, want %q", got.Message(), want.Message())
+	}
+}
+
+func TestCreateOrderValidationMatchesHTTP(t *testing.T) {
+	h := NewHandler(nil)
+
+	number := strings.Repeat("n", dto.MaxOrderNumberLen+1)
+	_, err := h.CreateOrder(context.Background(), &ordersv1.CreateOrderRequest{Number: number, Status: "pending"})
+
+	got := status.Convert(err)
+	if want := errorbank.Unprocessable("").GRPCCode(); got.Code() != want {
+		t.Fatalf("code = %s, want %s like the HTTP unprocessable_entity", got.Code(), want)
+	}
+	if got.Message() != "validation failed" {
+		t.Fatalf("message = %q, want validation failed", got.Message())
+	}
+}
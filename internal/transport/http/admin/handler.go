@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/maintenance"
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// TokenHeader carries the shared admin token.
+const TokenHeader = "X-Admin-Token"
+
+// Handler exposes guarded maintenance endpoints over HTTP.
+type Handler struct {
+	runner *maintenance.Runner
+	token  string
+}
+
+// NewHandler constructs an admin Handler.
+func NewHandler(runner *maintenance.Runner, cfg config.Config) *Handler {
+	return &Handler{runner: runner, token: cfg.Admin.Token}
+}
+
+// Register routes with provided Echo group. Nothing is mounted without a token.
+func Register(router *echo.Group, h *Handler) {
+	if h.token == "" {
+		return
+	}
+	g := router.Group("/admin", h.authorize)
+	g.GET("/queries", h.listQueries)
+	g.GET("/queries/:name", h.runQuery)
+}
+
+func (h *Handler) authorize(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		provided := c.Request().Header.Get(TokenHeader)
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(h.token)) != 1 {
+			return response.New(c).WithError(errorbank.Unauthorized("invalid admin token")).Build()
+		}
+		return next(c)
+	}
+}
+
+func (h *Handler) listQueries(c echo.Context) error {
+	type queryInfo struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Params      []string `json:"params"`
+	}
+	queries := maintenance.Queries()
+	data := make([]queryInfo, 0, len(queries))
+	for _, q := range queries {
+		data = append(data, queryInfo{Name: q.Name, Description: q.Description, Params: q.Params})
+	}
+	return response.New(c).WithData(data).Build()
+}
+
+func (h *Handler) runQuery(c echo.Context) error {
+	b := response.New(c)
+
+	params := make(map[string]string)
+	for key, values := range c.QueryParams() {
+		if len(values) > 0 {
+			params[key] = values[0]
+		}
+	}
+
+	rows, err := h.runner.Run(c.Request().Context(), c.Param("name"), params)
+	switch {
+	case errors.Is(err, maintenance.ErrUnknownQuery):
+		return b.WithError(errorbank.NotFound("unknown query", errorbank.WithCause(err))).Build()
+	case errors.Is(err, maintenance.ErrMissingParam):
+		return b.WithError(errorbank.BadRequest(err.Error())).Build()
+	case err != nil:
+		return b.WithError(errorbank.Internal("query failed", errorbank.WithCause(err))).Build()
+	}
+
+	return b.WithData(rows).WithMeta("count", len(rows)).Build()
+}
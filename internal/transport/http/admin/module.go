@@ -0,0 +1,15 @@
+package admin
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Module wires HTTP admin handlers.
+var Module = fx.Options(
+	fx.Provide(NewHandler),
+	fx.Invoke(func(router *echo.Group, h *Handler) {
+		Register(router, h)
+	}),
+)
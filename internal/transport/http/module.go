@@ -3,10 +3,12 @@ package http
 import (
 	"go.uber.org/fx"
 
+	admintransport "github.com/Additional-Code/atlas/internal/transport/http/admin"
 	ordertransport "github.com/Additional-Code/atlas/internal/transport/http/order"
 )
 
 // Module aggregates all HTTP transport handlers.
 var Module = fx.Options(
 	ordertransport.Module,
+	admintransport.Module,
 )
@@ -1,8 +1,15 @@
 package order
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -10,6 +17,7 @@ import (
 	"github.com/Additional-Code/atlas/internal/entity"
 	"github.com/Additional-Code/atlas/internal/presentation/http/response"
 	service "github.com/Additional-Code/atlas/internal/service/order"
+	"github.com/Additional-Code/atlas/internal/transport"
 	"github.com/Additional-Code/atlas/pkg/errorbank"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,6 +26,11 @@ import (
 
 var httpTracer = otel.Tracer("github.com/Additional-Code/atlas/transport/http/order")
 
+const defaultPerPage = 20
+
+// orderCacheMaxAge keeps cached order reads short-lived so updates show up quickly.
+const orderCacheMaxAge = 10 * time.Second
+
 // Handler exposes order endpoints over HTTP.
 type Handler struct {
 	svc *service.Service
@@ -29,8 +42,9 @@ func NewHandler(svc *service.Service) *Handler {
 }
 
 // Register routes with provided Echo group.
-func Register(e *echo.Echo, h *Handler) {
-	g := e.Group("/orders")
+func Register(router *echo.Group, h *Handler) {
+	g := router.Group("/orders")
+	g.GET("", h.list)
 	g.GET("/:id", h.getByID)
 	g.POST("", h.create)
 }
@@ -51,26 +65,168 @@ func (h *Handler) getByID(c echo.Context) error {
 		return b.WithError(err).Build()
 	}
 
-	return b.WithData(toDTO(order)).Build()
+	return b.WithData(dto.NewOrderResponse(order)).WithCacheControl(orderCacheMaxAge, true).Build()
+}
+
+func (h *Handler) list(c echo.Context) error {
+	b := response.New(c)
+
+	page, err := queryInt(c, "page", 1)
+	if err != nil || page < 1 {
+		return b.WithError(errorbank.BadRequest("page must be a positive integer", errorbank.WithDetail("page", c.QueryParam("page")))).Build()
+	}
+	perPage, err := queryInt(c, "per_page", defaultPerPage)
+	if err != nil || perPage < 1 || perPage > service.MaxListLimit {
+		return b.WithError(errorbank.BadRequest(
+			fmt.Sprintf("per_page must be between 1 and %d", service.MaxListLimit),
+			errorbank.WithDetail("per_page", c.QueryParam("per_page")),
+		)).Build()
+	}
+	if c.QueryParam("updated_since") != "" || c.QueryParam("cursor") != "" {
+		return h.listUpdatedSince(c, perPage)
+	}
+	var status entity.OrderStatus
+	if raw := c.QueryParam("status"); raw != "" {
+		if status, err = entity.ParseOrderStatus(raw); err != nil {
+			return b.WithError(service.InvalidStatusError(raw)).Build()
+		}
+	}
+
+	ctx, span := httpTracer.Start(c.Request().Context(), "orders.list", trace.WithAttributes(
+		attribute.Int("page", page),
+		attribute.Int("per_page", perPage),
+	))
+	defer span.End()
+
+	orders, total, err := h.svc.List(ctx, (page-1)*perPage, perPage, status)
+	if err != nil {
+		return b.WithError(err).Build()
+	}
+
+	items := make([]dto.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		items = append(items, dto.NewOrderResponse(order))
+	}
+
+	return b.WithData(items).
+		WithMeta("page", page).
+		WithMeta("per_page", perPage).
+		WithMeta("total", total).
+		WithMeta("total_pages", (total+perPage-1)/perPage).
+		Build()
+}
+
+// listUpdatedSince serves GET /orders?updated_since=<RFC3339> for incremental
+// sync. It pages by an opaque cursor rather than offset: meta.next_cursor is
+// passed back as ?cursor= on the next call and is absent once the caller is
+// caught up.
+func (h *Handler) listUpdatedSince(c echo.Context, perPage int) error {
+	b := response.New(c)
+
+	if c.QueryParam("page") != "" || c.QueryParam("status") != "" {
+		return b.WithError(errorbank.BadRequest("updated_since and cursor cannot be combined with page or status")).Build()
+	}
+	after, err := updatedCursor(c.QueryParam("updated_since"), c.QueryParam("cursor"))
+	if err != nil {
+		return b.WithError(err).Build()
+	}
+
+	ctx, span := httpTracer.Start(c.Request().Context(), "orders.listUpdatedSince", trace.WithAttributes(
+		attribute.String("updated_since", after.UpdatedAt.Format(time.RFC3339Nano)),
+		attribute.Int("per_page", perPage),
+	))
+	defer span.End()
+
+	orders, err := h.svc.ListUpdatedSince(ctx, after, perPage)
+	if err != nil {
+		return b.WithError(err).Build()
+	}
+
+	items := make([]dto.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		items = append(items, dto.NewOrderResponse(order))
+	}
+
+	b.WithData(items).WithMeta("per_page", perPage)
+	if len(orders) == perPage {
+		last := orders[len(orders)-1]
+		b.WithMeta("next_cursor", encodeCursor(service.UpdatedCursor{UpdatedAt: last.UpdatedAt, ID: last.ID}))
+	}
+	return b.Build()
+}
+
+// updatedCursor reads the starting position from exactly one of updated_since
+// or cursor. updated_since starts after every order updated at that instant.
+func updatedCursor(since, cursor string) (service.UpdatedCursor, error) {
+	switch {
+	case since != "" && cursor != "":
+		return service.UpdatedCursor{}, errorbank.BadRequest("pass either updated_since or cursor, not both")
+	case cursor != "":
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return service.UpdatedCursor{}, errorbank.BadRequest("cursor is invalid; pass back meta.next_cursor unchanged",
+				errorbank.WithDetail("cursor", cursor),
+				errorbank.WithCause(err),
+			)
+		}
+		return after, nil
+	default:
+		at, err := time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			return service.UpdatedCursor{}, errorbank.BadRequest("updated_since must be an RFC 3339 timestamp",
+				errorbank.WithDetail("updated_since", since),
+				errorbank.WithCause(err),
+			)
+		}
+		return service.UpdatedCursor{UpdatedAt: at, ID: math.MaxInt64}, nil
+	}
+}
+
+// encodeCursor renders after as the opaque next_cursor token.
+func encodeCursor(after service.UpdatedCursor) string {
+	raw := after.UpdatedAt.UTC().Format(time.RFC3339Nano) + "," + strconv.FormatInt(after.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (service.UpdatedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return service.UpdatedCursor{}, err
+	}
+	at, id, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return service.UpdatedCursor{}, errors.New("missing id")
+	}
+	var after service.UpdatedCursor
+	if after.UpdatedAt, err = time.Parse(time.RFC3339Nano, at); err != nil {
+		return service.UpdatedCursor{}, err
+	}
+	if after.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return service.UpdatedCursor{}, err
+	}
+	return after, nil
 }
 
 func (h *Handler) create(c echo.Context) error {
 	b := response.New(c)
 
-	var payload struct {
-		Number string `json:"number"`
-		Status string `json:"status"`
+	if err := requireContentType(c, echo.MIMEApplicationJSON, echo.MIMEApplicationForm, echo.MIMEMultipartForm); err != nil {
+		return b.WithError(err).Build()
 	}
-	if err := c.Bind(&payload); err != nil {
-		return b.WithError(errorbank.BadRequest("invalid payload", errorbank.WithCause(err))).Build()
+
+	payload, err := transport.BindAndValidate[dto.CreateOrderRequest](c)
+	if err != nil {
+		return b.WithError(err).Build()
 	}
-	if payload.Number == "" || payload.Status == "" {
-		return b.WithError(errorbank.BadRequest("number and status are required")).Build()
+
+	status, err := entity.ParseOrderStatus(payload.Status)
+	if err != nil {
+		return b.WithError(service.InvalidStatusError(payload.Status)).Build()
 	}
 
 	order := &entity.Order{
 		Number: payload.Number,
-		Status: payload.Status,
+		Status: status,
 	}
 
 	ctx, span := httpTracer.Start(c.Request().Context(), "orders.create")
@@ -83,15 +239,32 @@ func (h *Handler) create(c echo.Context) error {
 		return b.WithError(err).Build()
 	}
 
-	return b.WithStatus(http.StatusCreated).WithData(toDTO(order)).Build()
+	return b.WithStatus(http.StatusCreated).WithData(dto.NewOrderResponse(order)).Build()
+}
+
+// requireContentType rejects request bodies whose media type is not allowed,
+// so integrators get a precise error instead of a generic bind failure.
+func requireContentType(c echo.Context, allowed ...string) error {
+	header := c.Request().Header.Get(echo.HeaderContentType)
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err == nil {
+		for _, candidate := range allowed {
+			if strings.EqualFold(mediaType, candidate) {
+				return nil
+			}
+		}
+	}
+	return errorbank.UnsupportedMediaType("unsupported content type; expected one of "+strings.Join(allowed, ", "),
+		errorbank.WithDetail("content_type", header),
+		errorbank.WithDetail("supported", allowed),
+	)
 }
 
-func toDTO(order *entity.Order) dto.OrderResponse {
-	return dto.OrderResponse{
-		ID:        order.ID,
-		Number:    order.Number,
-		Status:    order.Status,
-		CreatedAt: order.CreatedAt,
-		UpdatedAt: order.UpdatedAt,
+// queryInt parses an integer query parameter, returning fallback when absent.
+func queryInt(c echo.Context, name string, fallback int) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return fallback, nil
 	}
+	return strconv.Atoi(raw)
 }
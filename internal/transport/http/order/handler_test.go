@@ -0,0 +1,70 @@
+package order
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	service "github.com/Additional-Code/atlas/internal/service/order"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+func TestRequireContentTypeListsAcceptedTypes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("x"))
+	req.Header.Set(echo.HeaderContentType, "text/plain")
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	err := requireContentType(c, echo.MIMEApplicationJSON, echo.MIMEApplicationForm, echo.MIMEMultipartForm)
+
+	var appErr *errorbank.AppError
+	if !errors.As(err, &appErr) || appErr.StatusCode() != http.StatusUnsupportedMediaType {
+		t.Fatalf("error = %v, want a 415 AppError", err)
+	}
+	for _, mediaType := range []string{echo.MIMEApplicationJSON, echo.MIMEApplicationForm, echo.MIMEMultipartForm} {
+		if !strings.Contains(appErr.Error(), mediaType) {
+			t.Errorf("message %q does not list %s", appErr.Error(), mediaType)
+		}
+	}
+}
+
+func TestRequireContentTypeAcceptsParameters(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("{}"))
+	req.Header.Set(echo.HeaderContentType, "application/json; charset=utf-8")
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+
+	if err := requireContentType(c, echo.MIMEApplicationJSON); err != nil {
+		t.Fatalf("requireContentType: %v", err)
+	}
+}
+
+func TestUpdatedCursorRoundTripsAndRejectsMixedParams(t *testing.T) {
+	want := service.UpdatedCursor{UpdatedAt: time.Date(2026, 3, 4, 5, 6, 7, 123456000, time.UTC), ID: 42}
+	got, err := updatedCursor("", encodeCursor(want))
+	if err != nil {
+		t.Fatalf("updatedCursor(next_cursor): %v", err)
+	}
+	if !got.UpdatedAt.Equal(want.UpdatedAt) || got.ID != want.ID {
+		t.Fatalf("cursor = %+v, want %+v", got, want)
+	}
+
+	since, err := updatedCursor("2026-03-04T05:06:07Z", "")
+	if err != nil || since.ID != math.MaxInt64 {
+		t.Fatalf("updatedCursor(updated_since) = %+v, %v; want every order at that instant excluded", since, err)
+	}
+
+	for name, params := range map[string][2]string{
+		"both":    {"2026-03-04T05:06:07Z", encodeCursor(want)},
+		"garbage": {"", "not-a-cursor"},
+	} {
+		var appErr *errorbank.AppError
+		if _, err := updatedCursor(params[0], params[1]); !errors.As(err, &appErr) || appErr.StatusCode() != http.StatusBadRequest {
+			t.Errorf("%s: error = %v, want a 400 AppError", name, err)
+		}
+	}
+}
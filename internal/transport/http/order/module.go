@@ -9,7 +9,7 @@ import (
 // Module wires HTTP order handlers.
 var Module = fx.Options(
 	fx.Provide(NewHandler),
-	fx.Invoke(func(e *echo.Echo, h *Handler) {
-		Register(e, h)
+	fx.Invoke(func(router *echo.Group, h *Handler) {
+		Register(router, h)
 	}),
 )
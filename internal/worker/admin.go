@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	echo "github.com/labstack/echo/v4"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/presentation/http/response"
+	"github.com/Additional-Code/atlas/pkg/errorbank"
+)
+
+// adminTokenHeader matches the API's admin token header.
+const adminTokenHeader = "X-Admin-Token"
+
+type drainStatus struct {
+	Draining bool `json:"draining"`
+	Drained  bool `json:"drained"`
+}
+
+// runAdmin serves the worker's operational endpoints on WORKER_ADMIN_ADDR:
+//
+//	POST /admin/drain   stop fetching and block until in-flight messages finish
+//	GET  /admin/drain   report drain progress
+//
+// Both require ADMIN_TOKEN; without it no listener is bound.
+func runAdmin(lc fx.Lifecycle, cfg config.Config, engine *Engine, logger *zap.Logger) {
+	addr := cfg.Messaging.Workers.AdminAddr
+	if addr == "" {
+		return
+	}
+	if cfg.Admin.Token == "" {
+		logger.Warn("worker admin listener disabled; ADMIN_TOKEN is not set", zap.String("addr", addr))
+
+		return
+	}
+
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+	g := e.Group("/admin", adminAuth(cfg.Admin.Token))
+	g.POST("/drain", func(c echo.Context) error {
+		if err := engine.Drain(c.Request().Context()); err != nil {
+			return response.New(c).WithError(errorbank.Unavailable("drain did not complete", errorbank.WithCause(err))).Build()
+		}
+		return response.New(c).WithData(drainStatus{Draining: true, Drained: true}).Build()
+	})
+	g.GET("/drain", func(c echo.Context) error {
+		return response.New(c).WithData(drainStatus{Draining: engine.Draining(), Drained: engine.Drained()}).Build()
+	})
+
+	server := &http.Server{Addr: addr, Handler: e}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("starting worker admin listener", zap.String("addr", addr))
+			go func() {
+				if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("worker admin listener failed", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
+
+func adminAuth(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			provided := c.Request().Header.Get(adminTokenHeader)
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				return response.New(c).WithError(errorbank.Unauthorized("invalid admin token")).Build()
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+// topicCoverage compares the topics the engine will read with those that have
+// handlers. The shared reader delivers KAFKA_TOPIC and KAFKA_TOPICS on top of
+// the handled topics; per-topic readers (pools, priorities) read handled
+// topics only, so configured topics without a handler are simply not read.
+func topicCoverage(cfg config.Kafka, handled []string, perTopic bool) (delivered, unhandled, unread []string) {
+	configured := config.MergeTopics(cfg.Topic, cfg.Topics...)
+	if perTopic {
+		delivered = slices.Clone(handled)
+	} else {
+		delivered = config.MergeTopics("", append(slices.Clone(handled), configured...)...)
+	}
+	slices.Sort(delivered)
+
+	for _, topic := range configured {
+		if slices.Contains(handled, topic) {
+			continue
+		}
+		if perTopic {
+			unread = append(unread, topic)
+		} else {
+			unhandled = append(unhandled, topic)
+		}
+	}
+	return delivered, unhandled, unread
+}
+
+// logTopicCoverage lists delivered and handled topics at startup so a handler
+// module missing from the Fx graph shows up at boot instead of as dropped
+// messages.
+func (e *Engine) logTopicCoverage(handled []string, perTopic bool) {
+	delivered, unhandled, unread := topicCoverage(e.cfg.Messaging.Kafka, handled, perTopic)
+	e.logger.Info("worker topic coverage",
+		zap.Strings("delivered", delivered),
+		zap.Strings("handled", handled),
+	)
+	if len(unhandled) > 0 {
+		e.logger.Warn("topics are delivered but have no handler; their messages are committed without processing",
+			zap.Strings("topics", unhandled),
+		)
+	}
+	if len(unread) > 0 {
+		e.logger.Warn("configured topics have no handler and are not consumed",
+			zap.Strings("topics", unread),
+		)
+	}
+}
@@ -0,0 +1,106 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// HeaderIdempotencyKey lets producers name a message so redeliveries and
+// republished copies are recognised as the same unit of work.
+const HeaderIdempotencyKey = "idempotency-key"
+
+// dedup remembers processed message keys in the shared cache store. Every key
+// is mirrored into a bounded local LRU, which is also consulted when the store
+// misses (a store that errors, evicts, or cannot persist keys), keeping
+// duplicates contained to best effort per instance.
+type dedup struct {
+	store    cache.Store
+	local    cache.Store
+	ttl      time.Duration
+	logger   *zap.Logger
+	degraded atomic.Bool
+}
+
+func newDedup(store cache.Store, cfg config.WorkerDedup, logger *zap.Logger) *dedup {
+	return &dedup{
+		store:  store,
+		local:  cache.NewLRU(cfg.LocalSize, cfg.TTL),
+		ttl:    cfg.TTL,
+		logger: logger,
+	}
+}
+
+// dedupKey prefers the producer-supplied idempotency key and otherwise uses
+// the message's broker coordinates, which are stable across redeliveries.
+func dedupKey(msg messaging.Message) string {
+	if key := msg.Headers[HeaderIdempotencyKey]; key != "" {
+		return "worker:dedup:" + msg.Topic + ":" + key
+	}
+	return fmt.Sprintf("worker:dedup:%s:%d:%d", msg.Topic, msg.Partition, msg.Offset)
+}
+
+// middleware skips messages already processed and records successful ones.
+func (d *dedup) middleware(next messaging.Handler) messaging.Handler {
+	return func(ctx context.Context, msg messaging.Message) error {
+		key := dedupKey(msg)
+		if d.seen(ctx, key) {
+			d.logger.Debug("skipping duplicate message", zap.String("topic", msg.Topic), zap.String("dedup_key", key))
+
+			return nil
+		}
+		if err := next(ctx, msg); err != nil {
+			return err
+		}
+		d.mark(ctx, key)
+
+		return nil
+	}
+}
+
+// seen reports whether key was already processed, falling back to the local
+// LRU when the store misses or errors.
+func (d *dedup) seen(ctx context.Context, key string) bool {
+	_, err := d.store.Get(ctx, key)
+	switch {
+	case err == nil:
+		d.recovered()
+		return true
+	case errors.Is(err, cache.ErrCacheMiss):
+		d.recovered()
+	default:
+		d.degrade(err)
+	}
+	_, err = d.local.Get(ctx, key)
+	return err == nil
+}
+
+// mark records key as processed.
+func (d *dedup) mark(ctx context.Context, key string) {
+	_ = d.local.Set(ctx, key, []byte{1}, d.ttl)
+	if err := d.store.Set(ctx, key, []byte{1}, d.ttl); err != nil {
+		d.degrade(err)
+		return
+	}
+	d.recovered()
+}
+
+func (d *dedup) degrade(err error) {
+	if d.degraded.CompareAndSwap(false, true) {
+		d.logger.Warn("dedup store unavailable; dedup degraded to local-only", zap.Error(err))
+	}
+}
+
+func (d *dedup) recovered() {
+	if d.degraded.CompareAndSwap(true, false) {
+		d.logger.Info("dedup store recovered; dedup is distributed again")
+	}
+}
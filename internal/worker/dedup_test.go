@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/cache"
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// forgetfulStore accepts writes but never returns them, like the noop store
+// or one that evicted the key.
+type forgetfulStore struct{ cache.Store }
+
+func (forgetfulStore) Get(context.Context, string) ([]byte, error) { return nil, cache.ErrCacheMiss }
+func (forgetfulStore) Set(context.Context, string, []byte, time.Duration) error {
+	return nil
+}
+
+func TestDedupFallsBackToLocalWhenStoreForgets(t *testing.T) {
+	d := newDedup(forgetfulStore{}, config.WorkerDedup{TTL: time.Minute, LocalSize: 16}, zap.NewNop())
+
+	calls := 0
+	handler := d.middleware(func(context.Context, messaging.Message) error {
+		calls++
+		return nil
+	})
+	msg := messaging.Message{Topic: "orders", Partition: 0, Offset: 7}
+	for range 2 {
+		if err := handler(context.Background(), msg); err != nil {
+			t.Fatalf("handler: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("handler calls = %d, want the redelivery skipped", calls)
+	}
+}
@@ -3,20 +3,31 @@ package worker
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
+	"github.com/Additional-Code/atlas/internal/cache"
 	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/logger"
 	"github.com/Additional-Code/atlas/internal/messaging"
+	"github.com/Additional-Code/atlas/internal/observability"
 )
 
+var engineTracer = otel.Tracer("github.com/Additional-Code/atlas/worker")
+
 // HandlerRegistration binds message topics to handlers.
 type HandlerRegistration struct {
 	Topic   string
 	Handler messaging.Handler
+	// Priority makes the engine service this topic before lower-priority ones
+	// whenever both have messages waiting. Leaving every registration at 0
+	// keeps the default fair consumption through a single reader.
+	Priority int
 }
 
 // Params collects dependencies via Fx.
@@ -24,40 +35,73 @@ type Params struct {
 	fx.In
 
 	Client        messaging.Client
+	Cache         cache.Store
 	Logger        *zap.Logger
+	Masker        *logger.Masker
 	Config        config.Config
-	Registrations []HandlerRegistration `group:"worker.handlers"`
+	Observability *observability.Manager
+	Registrations []HandlerRegistration    `group:"worker.handlers"`
+	Middleware    []MiddlewareRegistration `group:"worker.middleware"`
 }
 
 // Engine orchestrates background message consumption.
 type Engine struct {
 	client        messaging.Client
 	logger        *zap.Logger
+	masker        *logger.Masker
 	cfg           config.Config
 	registrations map[string]messaging.Handler
+	priorities    map[string]int
+	pools         map[string]int
 	cancel        context.CancelFunc
 	wg            *sync.WaitGroup
+	drain         chan struct{}
+	drainOnce     sync.Once
+	drained       chan struct{}
 }
 
-// NewEngine constructs the worker Engine.
+// NewEngine constructs the worker Engine. Every handler is wrapped, outermost
+// first, in tracing, panic recovery, the WORKER_HANDLER_TIMEOUT deadline,
+// dedup (when enabled), and then the registered middleware in priority order.
 func NewEngine(p Params) *Engine {
+	chain := []Middleware{
+		Tracing(p.Observability.Propagator()),
+		Recovery(p.Logger),
+		Timeout(p.Config.Messaging.Workers.HandlerTimeout),
+	}
+	if p.Config.Messaging.Workers.Dedup.Enabled {
+		chain = append(chain, newDedup(p.Cache, p.Config.Messaging.Workers.Dedup, p.Logger).middleware)
+	}
+	chain = append(chain, sortMiddleware(p.Middleware)...)
+
 	reg := make(map[string]messaging.Handler, len(p.Registrations))
+	priorities := make(map[string]int, len(p.Registrations))
 	for _, r := range p.Registrations {
 		if r.Topic == "" || r.Handler == nil {
 			continue
 		}
-		reg[r.Topic] = r.Handler
+		reg[r.Topic] = Chain(r.Handler, chain...)
+		priorities[r.Topic] = r.Priority
+	}
+	for topic := range p.Config.Messaging.Workers.TopicConcurrency {
+		if _, ok := reg[topic]; !ok {
+			p.Logger.Warn("WORKER_TOPIC_CONCURRENCY names a topic with no handler", zap.String("topic", topic))
+		}
 	}
 
 	return &Engine{
 		client:        p.Client,
 		logger:        p.Logger,
+		masker:        p.Masker,
 		cfg:           p.Config,
 		registrations: reg,
+		priorities:    priorities,
+		pools:         topicPools(reg, p.Config.Messaging.Workers.TopicConcurrency, p.Config.Messaging.Workers.Concurrency),
 	}
 }
 
-// Module wires the engine into Fx lifecycle.
+// Module wires the engine into Fx lifecycle and hands registered ScheduledJobs
+// to the scheduler.
 var Module = fx.Options(
 	fx.Provide(NewEngine),
 	fx.Invoke(func(lc fx.Lifecycle, engine *Engine) {
@@ -66,6 +110,11 @@ var Module = fx.Options(
 			OnStop:  engine.stop,
 		})
 	}),
+	fx.Invoke(runAdmin),
+	fx.Provide(fx.Annotate(scheduledJobs,
+		fx.ParamTags(ScheduledJobGroup),
+		fx.ResultTags(`group:"scheduler.jobs,flatten"`),
+	)),
 )
 
 func (e *Engine) start(ctx context.Context) error {
@@ -75,7 +124,10 @@ func (e *Engine) start(ctx context.Context) error {
 		return nil
 	}
 	if len(e.registrations) == 0 {
-		e.logger.Info("worker engine has no handlers; skipping")
+		// Usually a handler module missing from the Fx graph.
+		e.logger.Warn("worker engine has no handlers; skipping",
+			zap.Strings("configured_topics", config.MergeTopics(e.cfg.Messaging.Kafka.Topic, e.cfg.Messaging.Kafka.Topics...)),
+		)
 
 		return nil
 	}
@@ -85,45 +137,122 @@ func (e *Engine) start(ctx context.Context) error {
 		concurrency = 1
 	}
 
+	// Consume the union of every registered topic; messages dispatch by msg.Topic.
+	topics := make([]string, 0, len(e.registrations))
+	for topic := range e.registrations {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	e.client.Subscribe(topics...)
+
 	runCtx, cancel := context.WithCancel(context.Background())
 	e.cancel = cancel
 	e.wg = &sync.WaitGroup{}
+	e.drain = make(chan struct{})
+	e.drained = make(chan struct{})
+	runCtx = messaging.WithDrain(runCtx, e.drain)
 
-	for i := 0; i < concurrency; i++ {
-		workerID := i
-		e.wg.Add(1)
-		go func() {
-			defer e.wg.Done()
-			e.consumeLoop(runCtx, workerID)
-		}()
+	fetcher, canFetch := e.client.(messaging.TopicFetcher)
+	e.logTopicCoverage(topics, canFetch && (e.pools != nil || e.prioritized()))
+
+	workers := concurrency
+	switch {
+	case canFetch && e.pools != nil:
+		if e.prioritized() {
+			e.logger.Warn("per-topic worker pools configured; ignoring handler priorities")
+		}
+		workers = e.startPools(runCtx, fetcher)
+	case canFetch && e.prioritized():
+		e.startPrioritized(runCtx, fetcher, concurrency)
+	default:
+		if e.pools != nil || e.prioritized() {
+			e.logger.Warn("messaging client cannot read topics separately; ignoring per-topic pools and handler priorities")
+		}
+		for i := 0; i < concurrency; i++ {
+			workerID := i
+			e.wg.Add(1)
+			go func() {
+				defer e.wg.Done()
+				e.consumeLoop(runCtx, workerID)
+			}()
+		}
 	}
 
-	e.logger.Info("worker engine started", zap.Int("workers", concurrency))
+	go func() {
+		e.wg.Wait()
+		close(e.drained)
+	}()
+
+	e.logger.Info("worker engine started", zap.Int("workers", workers))
 
 	return nil
 }
 
-func (e *Engine) stop(ctx context.Context) error {
-	if e.cancel == nil {
+// Drain stops fetching new messages and waits until in-flight ones finish, or
+// ctx ends. It is safe to call repeatedly; later calls wait on the same drain.
+// The engine stays drained until the process stops.
+func (e *Engine) Drain(ctx context.Context) error {
+	if e.drain == nil {
 		return nil
 	}
-	e.cancel()
-	done := make(chan struct{})
-	go func() {
-		if e.wg != nil {
-			e.wg.Wait()
-		}
-		close(done)
-	}()
+	e.drainOnce.Do(func() {
+		e.logger.Info("worker drain requested; no longer fetching messages")
+		close(e.drain)
+		go func() {
+			<-e.drained
+			e.logger.Info("worker drained; safe to terminate")
+		}()
+	})
 
 	select {
+	case <-e.drained:
+		return nil
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-done:
-		e.logger.Info("worker engine stopped")
+	}
+}
+
+// Draining reports whether a drain was requested.
+func (e *Engine) Draining() bool {
+	return isClosed(e.drain)
+}
+
+// Drained reports whether a requested drain has completed.
+func (e *Engine) Drained() bool {
+	return e.Draining() && isClosed(e.drained)
+}
+
+func isClosed(ch chan struct{}) bool {
+	if ch == nil {
+		return false
+	}
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
 
+// stop shuts the engine down gracefully: fetching ends at once, while messages
+// already being handled get until ctx's deadline (the Fx stop timeout) to
+// finish and commit. Whatever is still running then is cancelled and, being
+// uncommitted, redelivered after restart.
+func (e *Engine) stop(ctx context.Context) error {
+	if e.cancel == nil {
 		return nil
 	}
+	defer e.cancel()
+
+	e.logger.Info("stopping worker engine; finishing in-flight messages")
+	if err := e.Drain(ctx); err != nil {
+		e.logger.Warn("worker engine stop timed out; cancelling in-flight messages", zap.Error(err))
+
+		return err
+	}
+	e.logger.Info("worker engine stopped")
+
+	return nil
 }
 
 func (e *Engine) consumeLoop(ctx context.Context, workerID int) {
@@ -133,18 +262,7 @@ func (e *Engine) consumeLoop(ctx context.Context, workerID int) {
 			return
 		}
 
-		err := e.client.Consume(ctx, func(msgCtx context.Context, msg messaging.Message) error {
-			handler, ok := e.registrations[msg.Topic]
-			if !ok {
-				e.logger.Warn("no handler for topic", zap.String("topic", msg.Topic))
-
-				return nil
-			}
-
-			e.logger.Debug("processing message", zap.String("topic", msg.Topic), zap.Int("worker", workerID))
-
-			return handler(msgCtx, msg)
-		})
+		err := e.client.Consume(ctx, e.dispatch(workerID))
 
 		if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			return
@@ -156,6 +274,8 @@ func (e *Engine) consumeLoop(ctx context.Context, workerID int) {
 		case <-time.After(backoff):
 		case <-ctx.Done():
 			return
+		case <-e.drain:
+			return
 		}
 
 		if backoff < 30*time.Second {
@@ -163,3 +283,23 @@ func (e *Engine) consumeLoop(ctx context.Context, workerID int) {
 		}
 	}
 }
+
+// dispatch returns the handler that routes a consumed message to the handler
+// registered for its topic.
+func (e *Engine) dispatch(workerID int) messaging.Handler {
+	return func(msgCtx context.Context, msg messaging.Message) error {
+		handler, ok := e.registrations[msg.Topic]
+		if !ok {
+			e.logger.Warn("no handler for topic", zap.String("topic", msg.Topic))
+
+			return nil
+		}
+
+		// Checked first so payloads are only masked when debug logging is on.
+		if entry := e.logger.Check(zap.DebugLevel, "processing message"); entry != nil {
+			entry.Write(zap.String("topic", msg.Topic), zap.Int("worker", workerID), e.masker.Field("payload", msg.Value))
+		}
+
+		return handler(msgCtx, msg)
+	}
+}
@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// queueClient delivers its queued messages through Consume, then returns.
+type queueClient struct {
+	messaging.Client
+	mu         sync.Mutex
+	queue      []messaging.Message
+	subscribed []string
+}
+
+func (c *queueClient) Subscribe(topics ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribed = append(c.subscribed, topics...)
+}
+
+func (c *queueClient) Consume(ctx context.Context, handler messaging.Handler) error {
+	for {
+		c.mu.Lock()
+		if len(c.queue) == 0 {
+			c.mu.Unlock()
+			return nil
+		}
+		msg := c.queue[0]
+		c.queue = c.queue[1:]
+		c.mu.Unlock()
+		_ = handler(ctx, msg)
+	}
+}
+
+func newTestEngine(client messaging.Client, cfg config.Config, registrations ...HandlerRegistration) *Engine {
+	cfg.Messaging.Enabled = true
+	cfg.Messaging.Workers.Enabled = true
+	return NewEngine(Params{
+		Client:        client,
+		Logger:        zap.NewNop(),
+		Config:        cfg,
+		Registrations: registrations,
+	})
+}
+
+// runEngine starts engine and stops it once its consumers have returned.
+func runEngine(t *testing.T, engine *Engine) {
+	t.Helper()
+	if err := engine.start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := engine.stop(context.Background()); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+}
+
+func TestEngineRoutesTopicsToTheirHandlers(t *testing.T) {
+	client := &queueClient{queue: []messaging.Message{
+		{Topic: "orders.created", Value: []byte("1")},
+		{Topic: "payments.settled", Value: []byte("2")},
+		{Topic: "orders.created", Value: []byte("3")},
+	}}
+	var mu sync.Mutex
+	got := map[string][]string{}
+	record := func(name string) messaging.Handler {
+		return func(_ context.Context, msg messaging.Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			got[name] = append(got[name], string(msg.Value))
+			return nil
+		}
+	}
+	engine := newTestEngine(client, config.Config{},
+		HandlerRegistration{Topic: "orders.created", Handler: record("orders")},
+		HandlerRegistration{Topic: "payments.settled", Handler: record("payments")},
+	)
+
+	runEngine(t, engine)
+
+	if want := []string{"orders.created", "payments.settled"}; !slices.Equal(client.subscribed, want) {
+		t.Fatalf("subscribed %v, want %v", client.subscribed, want)
+	}
+	if !slices.Equal(got["orders"], []string{"1", "3"}) || !slices.Equal(got["payments"], []string{"2"}) {
+		t.Fatalf("handled %v, want orders [1 3] and payments [2]", got)
+	}
+}
+
+// inFlightClient hands one message to the handler and commits it afterwards
+// unless the handler's context was cancelled, as the kafka client does.
+type inFlightClient struct {
+	messaging.Client
+	committed atomic.Bool
+}
+
+func (c *inFlightClient) Subscribe(...string) {}
+
+func (c *inFlightClient) Consume(ctx context.Context, handler messaging.Handler) error {
+	if err := handler(ctx, messaging.Message{Topic: "orders"}); err == nil && ctx.Err() == nil {
+		c.committed.Store(true)
+	}
+	return nil
+}
+
+func TestEngineStopLetsInFlightMessageFinishAndCommit(t *testing.T) {
+	client := &inFlightClient{}
+	entered, release := make(chan struct{}), make(chan struct{})
+	engine := newTestEngine(client, config.Config{}, HandlerRegistration{Topic: "orders", Handler: func(ctx context.Context, _ messaging.Message) error {
+		close(entered)
+		<-release
+		return ctx.Err()
+	}})
+	if err := engine.start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	<-entered
+
+	stopped := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		stopped <- engine.stop(ctx)
+	}()
+	select {
+	case err := <-stopped:
+		t.Fatalf("stop returned %v while a message was in flight", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if !client.committed.Load() {
+		t.Fatal("in-flight message was not committed")
+	}
+}
+
+func TestEngineKeepsConsumingAfterHandlerPanic(t *testing.T) {
+	client := &queueClient{queue: []messaging.Message{
+		{Topic: "orders", Value: []byte("boom")},
+		{Topic: "orders", Value: []byte("ok")},
+	}}
+	var handled []string
+	var results []error
+	engine := newTestEngine(client, config.Config{}, HandlerRegistration{Topic: "orders", Handler: func(_ context.Context, msg messaging.Message) error {
+		if string(msg.Value) == "boom" {
+			panic("handler bug")
+		}
+		handled = append(handled, string(msg.Value))
+		return nil
+	}})
+	handler := engine.dispatch(0)
+	for _, msg := range client.queue {
+		results = append(results, handler(context.Background(), msg))
+	}
+
+	if results[0] == nil {
+		t.Fatal("panicking handler returned no error for the retry path")
+	}
+	if results[1] != nil || !slices.Equal(handled, []string{"ok"}) {
+		t.Fatalf("after the panic: handled %v, err %v; want the next message handled", handled, results[1])
+	}
+
+	// The same holds through a running engine: the worker goroutine survives.
+	handled = nil
+	runEngine(t, engine)
+	if !slices.Equal(handled, []string{"ok"}) {
+		t.Fatalf("engine handled %v, want the message after the panic", handled)
+	}
+}
+
+// streamClient serves an endless stream of messages per topic through Fetch.
+type streamClient struct {
+	messaging.Client
+}
+
+func (streamClient) Subscribe(...string) {}
+
+func (streamClient) Fetch(ctx context.Context, topic string) (messaging.Delivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return streamDelivery{msg: messaging.Message{Topic: topic}}, nil
+}
+
+type streamDelivery struct{ msg messaging.Message }
+
+func (d streamDelivery) Message() messaging.Message { return d.msg }
+
+func (d streamDelivery) Process(ctx context.Context, handler messaging.Handler) {
+	_ = handler(ctx, d.msg)
+}
+
+func TestEngineSizesPerTopicPools(t *testing.T) {
+	var cfg config.Config
+	cfg.Messaging.Workers.Concurrency = 1
+	cfg.Messaging.Workers.TopicConcurrency = map[string]int{"orders": 3}
+
+	var mu sync.Mutex
+	active, peak := map[string]int{}, map[string]int{}
+	release := make(chan struct{})
+	handler := func(_ context.Context, msg messaging.Message) error {
+		mu.Lock()
+		active[msg.Topic]++
+		peak[msg.Topic] = max(peak[msg.Topic], active[msg.Topic])
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		active[msg.Topic]--
+		mu.Unlock()
+		return nil
+	}
+	engine := newTestEngine(streamClient{}, cfg,
+		HandlerRegistration{Topic: "orders", Handler: handler},
+		HandlerRegistration{Topic: "payments", Handler: handler},
+	)
+	if err := engine.start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	got := map[string]int{"orders": peak["orders"], "payments": peak["payments"]}
+	mu.Unlock()
+	close(release)
+	if err := engine.stop(context.Background()); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if got["orders"] != 3 || got["payments"] != 1 {
+		t.Fatalf("concurrent handlers = %v, want orders 3 and payments 1", got)
+	}
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// MiddlewareGroup is the Fx value group NewEngine collects middleware from.
+const MiddlewareGroup = `group:"worker.middleware"`
+
+// Middleware wraps a handler with a cross-cutting concern.
+type Middleware func(messaging.Handler) messaging.Handler
+
+// MiddlewareRegistration is a Middleware contributed by a feature module. Lower
+// priorities wrap higher ones, so they see the message first and the result
+// last. Ties are broken by Name to keep ordering deterministic. Registered
+// middleware runs inside the engine's built-in tracing, recovery, timeout, and
+// dedup, and wraps every topic's handler.
+type MiddlewareRegistration struct {
+	Name       string
+	Priority   int
+	Middleware Middleware
+}
+
+// AsMiddleware annotates a constructor returning MiddlewareRegistration so it
+// joins the middleware group, e.g. fx.Provide(worker.AsMiddleware(newTiming)).
+func AsMiddleware(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(MiddlewareGroup))
+}
+
+// Chain composes middleware around handler; the first one is outermost.
+func Chain(handler messaging.Handler, middleware ...Middleware) messaging.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// sortMiddleware orders registrations by priority, then name, dropping empties.
+func sortMiddleware(registrations []MiddlewareRegistration) []Middleware {
+	ordered := make([]MiddlewareRegistration, 0, len(registrations))
+	for _, m := range registrations {
+		if m.Middleware != nil {
+			ordered = append(ordered, m)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+
+	chain := make([]Middleware, len(ordered))
+	for i, m := range ordered {
+		chain[i] = m.Middleware
+	}
+	return chain
+}
+
+// Tracing continues the producer's trace from the message headers and wraps
+// the handler in a "worker.process" consumer span, recording any error.
+func Tracing(propagator propagation.TextMapPropagator) Middleware {
+	return func(next messaging.Handler) messaging.Handler {
+		return func(ctx context.Context, msg messaging.Message) error {
+			ctx = propagator.Extract(ctx, propagation.MapCarrier(msg.Headers))
+			ctx, span := engineTracer.Start(ctx, "worker.process", trace.WithSpanKind(trace.SpanKindConsumer), trace.WithAttributes(
+				attribute.String("messaging.topic", msg.Topic),
+				attribute.Int("messaging.partition", msg.Partition),
+				attribute.Int64("messaging.offset", msg.Offset),
+			))
+			defer span.End()
+
+			err := next(ctx, msg)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "handler failed")
+			}
+			return err
+		}
+	}
+}
+
+// Recovery converts a handler panic into an error so the message goes through
+// the usual retry and dead-letter path and the worker goroutine keeps consuming.
+func Recovery(logger *zap.Logger) Middleware {
+	return func(next messaging.Handler) messaging.Handler {
+		return func(ctx context.Context, msg messaging.Message) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				err = fmt.Errorf("worker handler panicked: %v", recovered)
+				logger.Error("worker handler panicked",
+					zap.Any("panic", recovered),
+					zap.String("topic", msg.Topic),
+					zap.Int("partition", msg.Partition),
+					zap.Int64("offset", msg.Offset),
+					zap.ByteString("stack", debug.Stack()),
+				)
+			}()
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Timeout cancels the handler's context after d; d <= 0 disables it. Handlers
+// must honour ctx for the deadline to take effect.
+func Timeout(d time.Duration) Middleware {
+	return func(next messaging.Handler) messaging.Handler {
+		if d <= 0 {
+			return next
+		}
+		return func(ctx context.Context, msg messaging.Message) error {
+			ctx, cancel := context.WithTimeout(ctx, d)
+			defer cancel()
+			return next(ctx, msg)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// recordCalls returns middleware appending "name>" before and "<name" after next.
+func recordCalls(name string, calls *[]string) Middleware {
+	return func(next messaging.Handler) messaging.Handler {
+		return func(ctx context.Context, msg messaging.Message) error {
+			*calls = append(*calls, name+">")
+			err := next(ctx, msg)
+			*calls = append(*calls, "<"+name)
+			return err
+		}
+	}
+}
+
+func TestRegisteredMiddlewareOrdersByPriorityThenName(t *testing.T) {
+	var calls []string
+	registrations := []MiddlewareRegistration{
+		{Name: "b", Priority: 10, Middleware: recordCalls("b", &calls)},
+		{Name: "skipped", Priority: 0},
+		{Name: "c", Priority: 5, Middleware: recordCalls("c", &calls)},
+		{Name: "a", Priority: 10, Middleware: recordCalls("a", &calls)},
+	}
+	handler := Chain(func(context.Context, messaging.Message) error {
+		calls = append(calls, "handler")
+		return nil
+	}, sortMiddleware(registrations)...)
+
+	if err := handler(context.Background(), messaging.Message{}); err != nil {
+		t.Fatalf("handler: %v", err)
+	}
+	want := []string{"c>", "a>", "b>", "handler", "<b", "<a", "<c"}
+	if !slices.Equal(calls, want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestEngineRunsRegisteredMiddlewareInsideTimeout(t *testing.T) {
+	var cfg config.Config
+	cfg.Messaging.Workers.HandlerTimeout = 20 * time.Millisecond
+	var sawDeadline bool
+	engine := NewEngine(Params{
+		Logger: zap.NewNop(),
+		Config: cfg,
+		Registrations: []HandlerRegistration{{Topic: "orders", Handler: func(ctx context.Context, _ messaging.Message) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}},
+		Middleware: []MiddlewareRegistration{{Name: "probe", Middleware: func(next messaging.Handler) messaging.Handler {
+			return func(ctx context.Context, msg messaging.Message) error {
+				_, sawDeadline = ctx.Deadline()
+				return next(ctx, msg)
+			}
+		}}},
+	})
+
+	start := time.Now()
+	err := engine.dispatch(0)(context.Background(), messaging.Message{Topic: "orders"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("handler error = %v, want the timeout to cancel it", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("handler ran %s, want it cut off near the 20ms timeout", elapsed)
+	}
+	if !sawDeadline {
+		t.Fatal("registered middleware ran outside the timeout")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/Additional-Code/atlas/internal/config"
+	atlaslogger "github.com/Additional-Code/atlas/internal/logger"
 	"github.com/Additional-Code/atlas/internal/messaging"
 	ordersvc "github.com/Additional-Code/atlas/internal/service/order"
 	"github.com/Additional-Code/atlas/internal/worker"
@@ -24,13 +25,15 @@ var Module = fx.Module("worker_order",
 	fx.Provide(
 		fx.Annotate(
 			NewOrderCreatedHandler,
-			fx.ResultTags(`group:"worker.handlers"`),
+			fx.ResultTags(`group:"worker.handlers,flatten"`),
 		),
 	),
 )
 
-// NewOrderCreatedHandler sets up a worker handler that logs order creations.
-func NewOrderCreatedHandler(logger *zap.Logger, cfg config.Config) worker.HandlerRegistration {
+// NewOrderCreatedHandler sets up a worker handler that logs order creations,
+// registered on KAFKA_TOPIC and every KAFKA_EVENT_TOPICS target so routed
+// events are consumed too.
+func NewOrderCreatedHandler(logger *zap.Logger, masker *atlaslogger.Masker, cfg config.Config) []worker.HandlerRegistration {
 	handler := func(ctx context.Context, msg messaging.Message) error {
 		ctx, span := workerTracer.Start(ctx, "worker.orders.process", trace.WithAttributes(
 			attribute.String("messaging.topic", msg.Topic),
@@ -39,12 +42,16 @@ func NewOrderCreatedHandler(logger *zap.Logger, cfg config.Config) worker.Handle
 
 		var event ordersvc.OrderCreatedEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			logger.Error("failed to decode order created", zap.Error(err))
+			logger.Error("failed to decode order created", zap.Error(err), masker.Field("payload", msg.Value))
 
 			span.RecordError(err)
 			span.SetStatus(codes.Error, "decode error")
 			return err
 		}
+		if event.Type != "" && event.Type != ordersvc.EventOrderCreated {
+			span.SetAttributes(attribute.String("order.event_type", event.Type))
+			return nil
+		}
 		logger.Info("order created event processed",
 			zap.Int64("id", event.ID),
 			zap.String("number", event.Number),
@@ -54,8 +61,10 @@ func NewOrderCreatedHandler(logger *zap.Logger, cfg config.Config) worker.Handle
 		return nil
 	}
 
-	return worker.HandlerRegistration{
-		Topic:   cfg.Messaging.Kafka.Topic,
-		Handler: handler,
+	topics := cfg.Messaging.Kafka.EventTopicList()
+	registrations := make([]worker.HandlerRegistration, 0, len(topics))
+	for _, topic := range topics {
+		registrations = append(registrations, worker.HandlerRegistration{Topic: topic, Handler: handler})
 	}
+	return registrations
 }
@@ -0,0 +1,34 @@
+package order
+
+import (
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	atlaslogger "github.com/Additional-Code/atlas/internal/logger"
+)
+
+func TestOrderCreatedHandlerRegistersRoutedTopics(t *testing.T) {
+	var cfg config.Config
+	cfg.Messaging.Kafka.Topic = "orders"
+	cfg.Messaging.Kafka.EventTopics = map[string]string{
+		"order.created":        "orders.created",
+		"order.status_changed": "orders.status",
+		"order.deleted":        "orders.created",
+	}
+
+	registrations := NewOrderCreatedHandler(zap.NewNop(), atlaslogger.NewMasker(nil), cfg)
+
+	topics := make([]string, 0, len(registrations))
+	for _, r := range registrations {
+		if r.Handler == nil {
+			t.Fatalf("registration for %q has no handler", r.Topic)
+		}
+		topics = append(topics, r.Topic)
+	}
+	if want := []string{"orders", "orders.created", "orders.status"}; !slices.Equal(topics, want) {
+		t.Fatalf("registered topics = %v, want %v", topics, want)
+	}
+}
@@ -0,0 +1,15 @@
+package outbox
+
+import (
+	"go.uber.org/fx"
+
+	"github.com/Additional-Code/atlas/internal/scheduler"
+)
+
+// Module runs the outbox relay as a scheduled job alongside the worker engine.
+var Module = fx.Module("worker_outbox",
+	fx.Provide(
+		NewRelay,
+		scheduler.AsJob((*Relay).Job),
+	),
+)
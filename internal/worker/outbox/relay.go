@@ -0,0 +1,226 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/entity"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	outboxrepo "github.com/Additional-Code/atlas/internal/repository/outbox"
+	"github.com/Additional-Code/atlas/internal/scheduler"
+)
+
+var relayTracer = otel.Tracer("github.com/Additional-Code/atlas/worker/outbox")
+
+const meterName = "github.com/Additional-Code/atlas/worker/outbox"
+
+// Params collects dependencies via Fx.
+type Params struct {
+	fx.In
+
+	Repository *outboxrepo.Repository
+	Client     messaging.Client
+	Config     config.Config
+	Logger     *zap.Logger
+}
+
+// Relay polls the outbox table and publishes pending events. Delivery is
+// at-least-once: a crash between publish and commit re-publishes the event.
+type Relay struct {
+	repo   *outboxrepo.Repository
+	client messaging.Client
+	logger *zap.Logger
+	cfg    config.Config
+
+	pending      atomic.Int64
+	published    metric.Int64Counter
+	failed       metric.Int64Counter
+	deadLettered metric.Int64Counter
+}
+
+// NewRelay constructs the outbox relay and registers its metrics.
+func NewRelay(p Params) (*Relay, error) {
+	r := &Relay{
+		repo:   p.Repository,
+		client: p.Client,
+		logger: p.Logger,
+		cfg:    p.Config,
+	}
+
+	meter := otel.Meter(meterName)
+	var err error
+	if r.published, err = meter.Int64Counter("outbox.published", metric.WithDescription("Outbox events published")); err != nil {
+		return nil, err
+	}
+	if r.failed, err = meter.Int64Counter("outbox.failed", metric.WithDescription("Outbox publish attempts that failed")); err != nil {
+		return nil, err
+	}
+	if r.deadLettered, err = meter.Int64Counter("outbox.dead_lettered", metric.WithDescription("Outbox events abandoned after max attempts")); err != nil {
+		return nil, err
+	}
+	_, err = meter.Int64ObservableGauge("outbox.pending",
+		metric.WithDescription("Outbox events awaiting publication as of the last poll"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(r.pending.Load())
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Job registers the relay with the scheduler. It opts out when the relay or
+// messaging is disabled.
+func (r *Relay) Job() scheduler.Job {
+	if !r.cfg.Outbox.RelayEnabled {
+		r.logger.Info("outbox relay disabled")
+
+		return scheduler.Job{}
+	}
+	if !r.cfg.Messaging.Enabled {
+		r.logger.Info("messaging disabled; outbox relay not started")
+
+		return scheduler.Job{}
+	}
+
+	r.logger.Info("outbox relay enabled",
+		zap.Duration("poll_interval", r.cfg.Messaging.Workers.PollInterval),
+		zap.Int("batch_size", r.cfg.Outbox.BatchSize),
+	)
+
+	return scheduler.Job{
+		Name:     "outbox.relay",
+		Interval: r.cfg.Messaging.Workers.PollInterval,
+		Run:      r.poll,
+	}
+}
+
+// poll drains full batches back to back and refreshes the pending gauge; the
+// scheduler runs it again on the next tick once caught up. A full batch in
+// which nothing was published ends the drain too, so an unreachable broker is
+// retried on the next tick rather than in a tight loop.
+func (r *Relay) poll(ctx context.Context) error {
+	defer r.refreshPending(ctx)
+
+	for {
+		claimed, published, err := r.relayBatch(ctx)
+		if err != nil {
+			return err
+		}
+		if claimed < r.cfg.Outbox.BatchSize || published == 0 {
+			return nil
+		}
+	}
+}
+
+// relayBatch claims one batch, publishes it, and records each outcome in the
+// same transaction that holds the row locks. It returns how many events were
+// claimed and how many of them were published.
+func (r *Relay) relayBatch(ctx context.Context) (int, int, error) {
+	ctx, span := relayTracer.Start(ctx, "outbox.relay")
+	defer span.End()
+
+	var claimed, published int
+	err := r.repo.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		claimed, published = 0, 0
+		events, err := r.repo.ClaimPending(ctx, tx, r.cfg.Outbox.BatchSize)
+		if err != nil {
+			return err
+		}
+		claimed = len(events)
+
+		for _, event := range events {
+			if err := r.publish(ctx, event); err != nil {
+				if err := r.recordFailure(ctx, tx, event, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.repo.MarkSent(ctx, tx, event.ID); err != nil {
+				return err
+			}
+			published++
+			r.published.Add(ctx, 1)
+		}
+		return nil
+	})
+	span.SetAttributes(attribute.Int("outbox.claimed", claimed), attribute.Int("outbox.published", published))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "relay failed")
+		return claimed, published, err
+	}
+	return claimed, published, nil
+}
+
+func (r *Relay) publish(ctx context.Context, event *entity.OutboxEvent) error {
+	return r.client.PublishMessage(ctx, messaging.Message{
+		Topic:   event.Topic,
+		Key:     event.Key,
+		Value:   event.Payload,
+		Headers: event.Headers,
+	})
+}
+
+func (r *Relay) recordFailure(ctx context.Context, tx bun.Tx, event *entity.OutboxEvent, cause error) error {
+	attempts := event.Attempts + 1
+	dead := attempts >= r.cfg.Outbox.MaxAttempts
+
+	r.failed.Add(ctx, 1)
+	if dead {
+		r.deadLettered.Add(ctx, 1)
+		r.logger.Error("outbox event dead-lettered",
+			zap.Int64("id", event.ID),
+			zap.String("aggregate_id", event.AggregateID),
+			zap.Int("attempts", attempts),
+			zap.Error(cause),
+		)
+	} else {
+		r.logger.Warn("outbox publish failed",
+			zap.Int64("id", event.ID),
+			zap.Int("attempts", attempts),
+			zap.Error(cause),
+		)
+	}
+
+	var nextAttempt time.Time
+	if !dead {
+		nextAttempt = time.Now().UTC().Add(retryBackoff(r.cfg.Outbox, attempts))
+	}
+	return r.repo.MarkFailed(ctx, tx, event.ID, attempts, cause.Error(), dead, nextAttempt)
+}
+
+// retryBackoff is the wait after an event's attempts-th failure: the
+// configured backoff doubled per earlier failure, capped at the maximum.
+func retryBackoff(cfg config.Outbox, attempts int) time.Duration {
+	backoff := cfg.RetryBackoff
+	for i := 1; i < attempts && backoff < cfg.RetryBackoffMax; i++ {
+		backoff *= 2
+	}
+	return min(backoff, cfg.RetryBackoffMax)
+}
+
+func (r *Relay) refreshPending(ctx context.Context) {
+	count, err := r.repo.CountPending(ctx)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			r.logger.Warn("outbox pending count failed", zap.Error(err))
+		}
+		return
+	}
+	r.pending.Store(int64(count))
+}
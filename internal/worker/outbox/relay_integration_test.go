@@ -0,0 +1,119 @@
+//go:build integration
+
+package outbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/database/dbtest"
+	"github.com/Additional-Code/atlas/internal/entity"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	outboxrepo "github.com/Additional-Code/atlas/internal/repository/outbox"
+)
+
+// recordingClient records published payloads in order, failing every publish
+// with err when it is set.
+type recordingClient struct {
+	messaging.Client
+	mu       sync.Mutex
+	payloads []string
+	err      error
+}
+
+func (c *recordingClient) PublishMessage(_ context.Context, msg messaging.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.payloads = append(c.payloads, string(msg.Value))
+	return nil
+}
+
+func newTestRelay(t *testing.T, db *bun.DB, client messaging.Client) *Relay {
+	t.Helper()
+	var cfg config.Config
+	cfg.Outbox = config.Outbox{BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Second, RetryBackoffMax: time.Second}
+	relay, err := NewRelay(Params{
+		Repository: outboxrepo.NewRepository(dbtest.Connections(db)),
+		Client:     client,
+		Config:     cfg,
+		Logger:     zap.NewNop(),
+	})
+	if err != nil {
+		t.Fatalf("NewRelay: %v", err)
+	}
+	return relay
+}
+
+func TestRelayPublishesAggregateEventsInSequence(t *testing.T) {
+	db := dbtest.Postgres(t)
+	dbtest.CreateTables(t, db, (*entity.OutboxEvent)(nil))
+
+	// Sequence 2 of order-1 is inserted before sequence 1, so id order and
+	// sequence order disagree.
+	events := []*entity.OutboxEvent{
+		{AggregateID: "order-1", Sequence: 2, Topic: "orders", Payload: []byte("order-1/2")},
+		{AggregateID: "order-2", Sequence: 1, Topic: "orders", Payload: []byte("order-2/1")},
+		{AggregateID: "order-1", Sequence: 1, Topic: "orders", Payload: []byte("order-1/1")},
+	}
+	for _, event := range events {
+		event.Status = entity.OutboxPending
+		if _, err := db.NewInsert().Model(event).Exec(t.Context()); err != nil {
+			t.Fatalf("insert event: %v", err)
+		}
+	}
+
+	client := &recordingClient{}
+	relay := newTestRelay(t, db, client)
+
+	for range len(events) {
+		if err := relay.poll(t.Context()); err != nil {
+			t.Fatalf("poll: %v", err)
+		}
+	}
+
+	var order1 []string
+	for _, payload := range client.payloads {
+		if strings.HasPrefix(payload, "order-1/") {
+			order1 = append(order1, payload)
+		}
+	}
+	if len(client.payloads) != 3 || len(order1) != 2 || order1[0] != "order-1/1" || order1[1] != "order-1/2" {
+		t.Fatalf("published %v, want order-1/1 before order-1/2 and all three sent", client.payloads)
+	}
+}
+
+func TestRelayLeavesEventPendingWhenPublishFails(t *testing.T) {
+	db := dbtest.Postgres(t)
+	dbtest.CreateTables(t, db, (*entity.OutboxEvent)(nil))
+	event := &entity.OutboxEvent{AggregateID: "order-1", Sequence: 1, Topic: "orders", Payload: []byte("v"), Status: entity.OutboxPending}
+	if _, err := db.NewInsert().Model(event).Exec(t.Context()); err != nil {
+		t.Fatalf("insert event: %v", err)
+	}
+
+	relay := newTestRelay(t, db, &recordingClient{err: errors.New("broker unavailable")})
+	if err := relay.poll(t.Context()); err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+
+	stored := new(entity.OutboxEvent)
+	if err := db.NewSelect().Model(stored).Where("id = ?", event.ID).Scan(t.Context()); err != nil {
+		t.Fatalf("load event: %v", err)
+	}
+	if stored.Status != entity.OutboxPending || !stored.SentAt.IsZero() {
+		t.Fatalf("event status %q sent_at %v, want pending and unsent", stored.Status, stored.SentAt)
+	}
+	if stored.Attempts != 1 || stored.LastError != "broker unavailable" || stored.NextAttemptAt.IsZero() {
+		t.Fatalf("event = %+v, want one recorded attempt with its error and a retry time", stored)
+	}
+}
@@ -0,0 +1,18 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Additional-Code/atlas/internal/config"
+)
+
+func TestRetryBackoffDoublesUpToMax(t *testing.T) {
+	cfg := config.Outbox{RetryBackoff: time.Second, RetryBackoffMax: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, expected := range want {
+		if got := retryBackoff(cfg, i+1); got != expected {
+			t.Errorf("attempt %d: backoff = %s, want %s", i+1, got, expected)
+		}
+	}
+}
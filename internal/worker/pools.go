@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// topicPools sizes a worker pool for every registered topic from
+// WORKER_TOPIC_CONCURRENCY, defaulting unlisted topics to WORKER_CONCURRENCY.
+// It returns nil when no per-topic sizes are configured.
+func topicPools(topics map[string]messaging.Handler, configured map[string]int, fallback int) map[string]int {
+	if len(configured) == 0 {
+		return nil
+	}
+	pools := make(map[string]int, len(topics))
+	for topic := range topics {
+		size, ok := configured[topic]
+		if !ok {
+			size = fallback
+		}
+		pools[topic] = size
+	}
+	return pools
+}
+
+// startPools reads every registered topic on its own reader and gives it a
+// dedicated pool of workers, so a slow topic cannot starve the others.
+func (e *Engine) startPools(ctx context.Context, fetcher messaging.TopicFetcher) int {
+	// Workers finish what is in hand and exit once their source is closed.
+	fetchCtx := e.fetchContext(ctx)
+
+	total := 0
+	for topic, size := range e.pools {
+		source := topicSource{topic: topic, deliveries: make(chan messaging.Delivery, size)}
+
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			e.fetchLoop(fetchCtx, fetcher, source)
+		}()
+
+		for i := 0; i < size; i++ {
+			workerID := total
+			total++
+			e.wg.Add(1)
+			go func() {
+				defer e.wg.Done()
+				e.poolLoop(ctx, source, workerID)
+			}()
+		}
+	}
+
+	e.logger.Info("worker engine consuming with per-topic pools", zap.Any("pools", e.pools))
+
+	return total
+}
+
+func (e *Engine) poolLoop(ctx context.Context, source topicSource, workerID int) {
+	handler := e.dispatch(workerID)
+	for {
+		select {
+		case delivery, ok := <-source.deliveries:
+			if !ok {
+				return
+			}
+			delivery.Process(ctx, handler)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
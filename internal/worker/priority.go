@@ -0,0 +1,213 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+// topicSource carries deliveries fetched ahead from one topic's reader.
+type topicSource struct {
+	topic      string
+	deliveries chan messaging.Delivery
+}
+
+// prioritized reports whether any registration asked for a non-default priority.
+func (e *Engine) prioritized() bool {
+	for _, priority := range e.priorities {
+		if priority != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// startPrioritized reads every registered topic on its own reader, fetching up
+// to concurrency messages ahead per topic, and runs workers that always take a
+// message from the highest-priority topic that has one. Topics sharing a
+// priority are served in random order.
+func (e *Engine) startPrioritized(ctx context.Context, fetcher messaging.TopicFetcher, concurrency int) {
+	// Workers finish what is in hand and exit once every source is closed.
+	fetchCtx := e.fetchContext(ctx)
+
+	byPriority := make(map[int][]topicSource)
+	for topic, priority := range e.priorities {
+		// Buffering one message per worker keeps a busy high-priority topic
+		// ready whenever a worker frees up, instead of racing its fetcher.
+		source := topicSource{topic: topic, deliveries: make(chan messaging.Delivery, concurrency)}
+		byPriority[priority] = append(byPriority[priority], source)
+
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			e.fetchLoop(fetchCtx, fetcher, source)
+		}()
+	}
+
+	levels := make([]int, 0, len(byPriority))
+	for priority := range byPriority {
+		levels = append(levels, priority)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	tiers := make([][]topicSource, 0, len(levels))
+	for _, priority := range levels {
+		tiers = append(tiers, byPriority[priority])
+	}
+
+	for i := 0; i < concurrency; i++ {
+		workerID := i
+		e.wg.Add(1)
+		go func() {
+			defer e.wg.Done()
+			e.priorityLoop(ctx, tiers, workerID)
+		}()
+	}
+
+	e.logger.Info("worker engine consuming by topic priority", zap.Any("priorities", e.priorities))
+}
+
+// fetchContext is ctx, additionally cancelled when a drain starts, so draining
+// stops the fetchers while workers keep processing.
+func (e *Engine) fetchContext(ctx context.Context) context.Context {
+	fetchCtx, stopFetch := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-e.drain:
+		case <-fetchCtx.Done():
+		}
+		stopFetch()
+	}()
+	return fetchCtx
+}
+
+// fetchLoop feeds source until ctx ends. Messages fetched but never handed to
+// a worker stay uncommitted and are redelivered later.
+func (e *Engine) fetchLoop(ctx context.Context, fetcher messaging.TopicFetcher, source topicSource) {
+	defer close(source.deliveries)
+
+	backoff := time.Second
+	for {
+		delivery, err := fetcher.Fetch(ctx, source.topic)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return
+			}
+			e.logger.Error("topic fetch failed", zap.String("topic", source.topic), zap.Error(err))
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+			if backoff < 30*time.Second {
+				backoff *= 2
+			}
+			continue
+		}
+		backoff = time.Second
+
+		select {
+		case source.deliveries <- delivery:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *Engine) priorityLoop(ctx context.Context, tiers [][]topicSource, workerID int) {
+	handler := e.dispatch(workerID)
+	closed := make(map[string]bool)
+	for {
+		delivery, ok := nextDelivery(ctx, tiers, closed)
+		if !ok {
+			return
+		}
+		delivery.Process(ctx, handler)
+	}
+}
+
+// nextDelivery polls tiers from highest priority down and returns the first
+// ready delivery. When none is ready it blocks on every open source. It
+// reports false once ctx ends or every source is closed.
+func nextDelivery(ctx context.Context, tiers [][]topicSource, closed map[string]bool) (messaging.Delivery, bool) {
+	var all []topicSource
+	for _, tier := range tiers {
+		all = append(all, tier...)
+	}
+
+	for ctx.Err() == nil {
+		var delivery messaging.Delivery
+		status := nothingReady
+		for _, tier := range tiers {
+			if delivery, status = receive(ctx, tier, closed, false); status != nothingReady {
+				break
+			}
+		}
+		if status == nothingReady {
+			delivery, status = receive(ctx, all, closed, true)
+		}
+
+		switch status {
+		case received:
+			return delivery, true
+		case stopped:
+			return nil, false
+		}
+		// A source closed; poll again from the top.
+	}
+	return nil, false
+}
+
+type receiveStatus int
+
+const (
+	nothingReady receiveStatus = iota
+	received
+	sourceClosed
+	stopped
+)
+
+// receive takes one delivery from any open source in sources, picking at
+// random among ready ones. Without block it returns nothingReady when none is
+// ready; with block it waits, returning stopped when ctx ends or no source is
+// open.
+func receive(ctx context.Context, sources []topicSource, closed map[string]bool, block bool) (messaging.Delivery, receiveStatus) {
+	cases := make([]reflect.SelectCase, 0, len(sources)+1)
+	open := make([]topicSource, 0, len(sources))
+	for _, source := range sources {
+		if closed[source.topic] {
+			continue
+		}
+		open = append(open, source)
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(source.deliveries)})
+	}
+	if len(open) == 0 {
+		if block {
+			return nil, stopped
+		}
+		return nil, nothingReady
+	}
+	if block {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())})
+	} else {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+	}
+
+	chosen, value, ok := reflect.Select(cases)
+	switch {
+	case chosen == len(open) && block:
+		return nil, stopped
+	case chosen == len(open):
+		return nil, nothingReady
+	case !ok:
+		closed[open[chosen].topic] = true
+		return nil, sourceClosed
+	}
+	return value.Interface().(messaging.Delivery), received
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+
+	"github.com/Additional-Code/atlas/internal/scheduler"
+)
+
+// ScheduledJobGroup is the Fx value group scheduled jobs are collected from.
+const ScheduledJobGroup = `group:"worker.scheduled"`
+
+// ScheduledJob is periodic work triggered by a cron expression rather than a
+// message, e.g. expiring stale orders. Jobs run on the worker's scheduler on
+// the elected leader only, so each firing happens once across replicas; a run
+// never overlaps the previous one and its ctx is cancelled on shutdown.
+type ScheduledJob struct {
+	Name string
+	// Schedule is a five-field cron expression or descriptor such as "@daily".
+	Schedule string
+	Handler  func(ctx context.Context) error
+}
+
+// AsScheduledJob annotates a constructor returning ScheduledJob so it joins
+// the scheduled job group, e.g. fx.Provide(worker.AsScheduledJob(newExpiry)).
+func AsScheduledJob(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(ScheduledJobGroup))
+}
+
+// scheduledJobs hands registered ScheduledJobs to the scheduler, dropping
+// those without a handler.
+func scheduledJobs(registrations []ScheduledJob) []scheduler.Job {
+	jobs := make([]scheduler.Job, 0, len(registrations))
+	for _, r := range registrations {
+		if r.Handler == nil {
+			continue
+		}
+		jobs = append(jobs, scheduler.Job{
+			Name:      r.Name,
+			Cron:      r.Schedule,
+			Run:       r.Handler,
+			Singleton: true,
+		})
+	}
+	return jobs
+}
@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+	"github.com/Additional-Code/atlas/internal/observability"
+)
+
+var webhookTracer = otel.Tracer("github.com/Additional-Code/atlas/worker/webhook")
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with WEBHOOK_SECRET, prefixed "sha256=", so
+// receivers can verify origin and reject stale replays.
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Module runs webhook delivery alongside the worker engine.
+var Module = fx.Module("worker_webhook",
+	fx.Provide(NewDeliverer),
+	fx.Invoke(func(lc fx.Lifecycle, d *Deliverer) {
+		lc.Append(fx.Hook{
+			OnStart: d.start,
+			OnStop:  d.stop,
+		})
+	}),
+)
+
+// Params collects dependencies via Fx.
+type Params struct {
+	fx.In
+
+	Lifecycle     fx.Lifecycle
+	Config        config.Config
+	Observability *observability.Manager
+	Logger        *zap.Logger
+}
+
+// Deliverer consumes events on KAFKA_TOPIC and the KAFKA_EVENT_TOPICS targets
+// and POSTs each one to every WEBHOOK_URLS endpoint. It reads through its own
+// consumer group, so a slow or failing endpoint never holds back the worker
+// engine. A failed delivery is retried and dead-lettered by the messaging
+// client like any handler error; since a retry re-sends to every endpoint,
+// receivers should deduplicate on X-Webhook-ID.
+type Deliverer struct {
+	cfg        config.Webhook
+	client     messaging.Client
+	topics     []string
+	http       *http.Client
+	propagator propagation.TextMapPropagator
+	logger     *zap.Logger
+
+	cancel context.CancelFunc
+	drain  chan struct{}
+	done   chan struct{}
+}
+
+// NewDeliverer builds the deliverer and, when webhooks and messaging are both
+// enabled, its dedicated messaging client.
+func NewDeliverer(p Params) (*Deliverer, error) {
+	d := &Deliverer{
+		cfg:        p.Config.Webhook,
+		http:       &http.Client{Timeout: p.Config.Webhook.Timeout},
+		propagator: p.Observability.Propagator(),
+		logger:     p.Logger,
+	}
+	if len(d.cfg.URLs) == 0 || !p.Config.Messaging.Enabled {
+		return d, nil
+	}
+
+	clientCfg := clientConfig(p.Config)
+	d.topics = clientCfg.Messaging.Kafka.Topics
+	client, err := messaging.NewClient(p.Lifecycle, clientCfg, p.Observability, p.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("webhook messaging client: %w", err)
+	}
+	d.client = client
+	return d, nil
+}
+
+// clientConfig derives the deliverer's messaging settings from cfg.
+func clientConfig(cfg config.Config) config.Config {
+	// KAFKA_TOPIC and the KAFKA_EVENT_TOPICS targets carry the events
+	// webhooks deliver.
+	cfg.Messaging.Kafka.Topics = cfg.Messaging.Kafka.EventTopicList()
+	cfg.Messaging.ConsumerGroup = cfg.Webhook.ConsumerGroup
+	// Failed deliveries always go to the webhook DLQ, whatever
+	// KAFKA_DLQ_ENABLED says, so the deliverer never holds its partition.
+	cfg.Messaging.Kafka.DLQ.Enabled = true
+	cfg.Messaging.Kafka.DLQ.Topic = cfg.Webhook.DLQTopic
+	return cfg
+}
+
+func (d *Deliverer) start(context.Context) error {
+	if len(d.cfg.URLs) == 0 {
+		d.logger.Info("webhook delivery disabled")
+
+		return nil
+	}
+	if d.client == nil {
+		d.logger.Info("messaging disabled; webhook delivery not started")
+
+		return nil
+	}
+
+	d.client.Subscribe(d.topics...)
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.drain = make(chan struct{})
+	d.done = make(chan struct{})
+	runCtx = messaging.WithDrain(runCtx, d.drain)
+
+	go func() {
+		defer close(d.done)
+		d.consumeLoop(runCtx)
+	}()
+
+	d.logger.Info("webhook delivery started",
+		zap.Strings("topics", d.topics),
+		zap.Int("endpoints", len(d.cfg.URLs)),
+	)
+
+	return nil
+}
+
+// stop lets the delivery in progress finish within ctx, then cancels it.
+func (d *Deliverer) stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+	defer d.cancel()
+	close(d.drain)
+
+	select {
+	case <-d.done:
+		d.logger.Info("webhook delivery stopped")
+
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Deliverer) consumeLoop(ctx context.Context) {
+	backoff := time.Second
+	for {
+		err := d.client.Consume(ctx, d.deliver)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		d.logger.Error("webhook consume loop error", zap.Error(err))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		case <-d.drain:
+			return
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// deliver POSTs msg to every endpoint, failing if any endpoint does.
+func (d *Deliverer) deliver(ctx context.Context, msg messaging.Message) error {
+	ctx = d.propagator.Extract(ctx, propagation.MapCarrier(msg.Headers))
+	ctx, span := webhookTracer.Start(ctx, "worker.webhook.deliver", trace.WithAttributes(
+		attribute.String("messaging.topic", msg.Topic),
+		attribute.Int64("messaging.offset", msg.Offset),
+	))
+	defer span.End()
+
+	id := fmt.Sprintf("%s-%d-%d", msg.Topic, msg.Partition, msg.Offset)
+	var wg sync.WaitGroup
+	errs := make([]error, len(d.cfg.URLs))
+	for i, target := range d.cfg.URLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = d.post(ctx, target, id, msg.Value)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "delivery failed")
+		return err
+	}
+	return nil
+}
+
+func (d *Deliverer) post(ctx context.Context, target, id string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", target, err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, id)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(d.cfg.Secret, timestamp, body))
+	d.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := d.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: unexpected status %d", target, resp.StatusCode)
+	}
+	d.logger.Debug("webhook delivered", zap.String("url", target), zap.String("id", id))
+
+	return nil
+}
+
+// Sign returns the X-Webhook-Signature value for body sent at timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
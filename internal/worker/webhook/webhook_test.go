@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.uber.org/zap"
+
+	"github.com/Additional-Code/atlas/internal/config"
+	"github.com/Additional-Code/atlas/internal/messaging"
+)
+
+func TestClientConfigAlwaysEnablesDLQ(t *testing.T) {
+	var cfg config.Config
+	cfg.Messaging.Kafka.Topic = "orders"
+	cfg.Messaging.Kafka.EventTopics = map[string]string{"order.created": "orders.created"}
+	cfg.Messaging.Kafka.DLQ = config.KafkaDLQ{Enabled: false, Topic: "orders.dlq"}
+	cfg.Webhook.ConsumerGroup = "atlas-webhook"
+	cfg.Webhook.DLQTopic = "orders.webhook.dlq"
+
+	got := clientConfig(cfg)
+
+	if !got.Messaging.Kafka.DLQ.Enabled || got.Messaging.Kafka.DLQ.Topic != "orders.webhook.dlq" {
+		t.Fatalf("DLQ = %+v, want enabled on orders.webhook.dlq", got.Messaging.Kafka.DLQ)
+	}
+	if got.Messaging.ConsumerGroup != "atlas-webhook" {
+		t.Fatalf("consumer group = %q, want atlas-webhook", got.Messaging.ConsumerGroup)
+	}
+	if want := []string{"orders", "orders.created"}; !slices.Equal(got.Messaging.Kafka.Topics, want) {
+		t.Fatalf("topics = %v, want %v", got.Messaging.Kafka.Topics, want)
+	}
+	if cfg.Messaging.Kafka.DLQ.Enabled {
+		t.Fatal("clientConfig modified the shared config")
+	}
+}
+
+func TestDeliverSignsEveryEndpointAndFailsOnErrorStatus(t *testing.T) {
+	const secret = "s3cret"
+	body := []byte(`{"id":42}`)
+
+	var (
+		mu   sync.Mutex
+		hits = map[string]int{}
+	)
+	endpoint := func(name string, status int) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ := io.ReadAll(r.Body)
+			timestamp := r.Header.Get(HeaderTimestamp)
+			if want := Sign(secret, timestamp, got); r.Header.Get(HeaderSignature) != want {
+				t.Errorf("%s: signature = %q, want %q", name, r.Header.Get(HeaderSignature), want)
+			}
+			if ts, err := strconv.ParseInt(timestamp, 10, 64); err != nil || time.Since(time.Unix(ts, 0)) > time.Minute {
+				t.Errorf("%s: timestamp = %q, want the current unix time", name, timestamp)
+			}
+			if id := r.Header.Get(HeaderID); id != "orders-2-7" {
+				t.Errorf("%s: id = %q, want orders-2-7", name, id)
+			}
+			if string(got) != string(body) {
+				t.Errorf("%s: body = %s, want %s", name, got, body)
+			}
+			mu.Lock()
+			hits[name]++
+			mu.Unlock()
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	ok := endpoint("ok", http.StatusNoContent)
+	failing := endpoint("failing", http.StatusInternalServerError)
+
+	newDeliverer := func(urls ...string) *Deliverer {
+		return &Deliverer{
+			cfg:        config.Webhook{URLs: urls, Secret: secret},
+			http:       &http.Client{Timeout: time.Second},
+			propagator: propagation.TraceContext{},
+			logger:     zap.NewNop(),
+		}
+	}
+	msg := messaging.Message{Topic: "orders", Partition: 2, Offset: 7, Value: body}
+
+	if err := newDeliverer(ok.URL).deliver(context.Background(), msg); err != nil {
+		t.Fatalf("deliver to a 204 endpoint: %v", err)
+	}
+	if err := newDeliverer(ok.URL, failing.URL).deliver(context.Background(), msg); err == nil {
+		t.Fatal("deliver succeeded although one endpoint returned 500")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if hits["ok"] != 2 || hits["failing"] != 1 {
+		t.Fatalf("requests per endpoint = %v, want ok:2 failing:1", hits)
+	}
+}
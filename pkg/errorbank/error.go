@@ -13,12 +13,31 @@ type Kind string
 
 const (
 	KindBadRequest          Kind = "bad_request"
+	KindUnauthorized        Kind = "unauthorized"
 	KindConflict            Kind = "conflict"
 	KindNotFound            Kind = "not_found"
 	KindUnprocessableEntity Kind = "unprocessable_entity"
+	KindUnsupportedMedia    Kind = "unsupported_media_type"
+	KindTooManyRequests     Kind = "too_many_requests"
+	KindUnavailable         Kind = "unavailable"
 	KindInternal            Kind = "internal"
 )
 
+// Kinds lists every supported error kind.
+func Kinds() []Kind {
+	return []Kind{
+		KindBadRequest,
+		KindUnauthorized,
+		KindConflict,
+		KindNotFound,
+		KindUnprocessableEntity,
+		KindUnsupportedMedia,
+		KindTooManyRequests,
+		KindUnavailable,
+		KindInternal,
+	}
+}
+
 // AppError captures rich error context shared across transports.
 type AppError struct {
 	kind    Kind
@@ -125,12 +144,20 @@ func (e *AppError) StatusCode() int {
 	switch e.kind {
 	case KindBadRequest:
 		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
 	case KindConflict:
 		return http.StatusConflict
 	case KindNotFound:
 		return http.StatusNotFound
 	case KindUnprocessableEntity:
 		return http.StatusUnprocessableEntity
+	case KindUnsupportedMedia:
+		return http.StatusUnsupportedMediaType
+	case KindTooManyRequests:
+		return http.StatusTooManyRequests
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusInternalServerError
 	}
@@ -144,12 +171,20 @@ func (e *AppError) GRPCCode() codes.Code {
 	switch e.kind {
 	case KindBadRequest:
 		return codes.InvalidArgument
+	case KindUnauthorized:
+		return codes.Unauthenticated
 	case KindConflict:
 		return codes.AlreadyExists
 	case KindNotFound:
 		return codes.NotFound
 	case KindUnprocessableEntity:
 		return codes.FailedPrecondition
+	case KindUnsupportedMedia:
+		return codes.InvalidArgument
+	case KindTooManyRequests:
+		return codes.ResourceExhausted
+	case KindUnavailable:
+		return codes.Unavailable
 	default:
 		return codes.Internal
 	}
@@ -160,6 +195,11 @@ func BadRequest(message string, opts ...Option) *AppError {
 	return New(KindBadRequest, message, opts...)
 }
 
+// Unauthorized constructs a 401 error.
+func Unauthorized(message string, opts ...Option) *AppError {
+	return New(KindUnauthorized, message, opts...)
+}
+
 // Conflict constructs a 409 error.
 func Conflict(message string, opts ...Option) *AppError {
 	return New(KindConflict, message, opts...)
@@ -175,6 +215,21 @@ func Unprocessable(message string, opts ...Option) *AppError {
 	return New(KindUnprocessableEntity, message, opts...)
 }
 
+// UnsupportedMediaType constructs a 415 error.
+func UnsupportedMediaType(message string, opts ...Option) *AppError {
+	return New(KindUnsupportedMedia, message, opts...)
+}
+
+// TooManyRequests constructs a 429 error for requests rejected under load.
+func TooManyRequests(message string, opts ...Option) *AppError {
+	return New(KindTooManyRequests, message, opts...)
+}
+
+// Unavailable constructs a 503 error for temporarily shed load.
+func Unavailable(message string, opts ...Option) *AppError {
+	return New(KindUnavailable, message, opts...)
+}
+
 // Internal constructs a generic 500 error.
 func Internal(message string, opts ...Option) *AppError {
 	return New(KindInternal, message, opts...)
@@ -0,0 +1,64 @@
+package errorbank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCErrorDomain is the ErrorInfo domain attached by ToGRPCStatus.
+const GRPCErrorDomain = "atlas"
+
+// ToGRPCStatus converts err into a gRPC status carrying the AppError's code and
+// message plus an ErrorInfo detail: the reason is the upper-cased kind (e.g.
+// NOT_FOUND) and the metadata holds Details(), with non-string values JSON
+// encoded. Errors that are not AppErrors become Internal, as with From; nil
+// yields an OK status. Each conversion is counted by RecordRendered.
+func ToGRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	appErr := From(err)
+	RecordRendered(context.Background(), appErr)
+	st := status.New(appErr.GRPCCode(), appErr.Message())
+
+	info := &errdetails.ErrorInfo{
+		Reason:   strings.ToUpper(string(appErr.Kind())),
+		Domain:   GRPCErrorDomain,
+		Metadata: detailMetadata(appErr.Details()),
+	}
+	withInfo, detailErr := st.WithDetails(info)
+	if detailErr != nil {
+		return st
+	}
+	return withInfo
+}
+
+// detailMetadata flattens details into the string map ErrorInfo accepts.
+func detailMetadata(details map[string]any) map[string]string {
+	if len(details) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(details))
+	for key, value := range details {
+		switch v := value.(type) {
+		case string:
+			metadata[key] = v
+		case fmt.Stringer:
+			metadata[key] = v.String()
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				metadata[key] = fmt.Sprint(v)
+				continue
+			}
+			metadata[key] = string(encoded)
+		}
+	}
+	return metadata
+}
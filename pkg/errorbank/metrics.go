@@ -0,0 +1,34 @@
+package errorbank
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const meterName = "github.com/Additional-Code/atlas/errorbank"
+
+// renderedCounter is created on first use through the global meter provider,
+// which forwards to the one observability installs at startup.
+var renderedCounter = sync.OnceValue(func() metric.Int64Counter {
+	counter, err := otel.Meter(meterName).Int64Counter("app.errors",
+		metric.WithDescription("AppErrors rendered to clients by kind"),
+	)
+	if err != nil {
+		return noop.Int64Counter{}
+	}
+	return counter
+})
+
+// RecordRendered counts appErr as returned to a client, as app_errors_total
+// labeled by kind. Transports call it once per error response they render.
+func RecordRendered(ctx context.Context, appErr *AppError) {
+	if appErr == nil {
+		return
+	}
+	renderedCounter().Add(ctx, 1, metric.WithAttributes(attribute.String("kind", string(appErr.Kind()))))
+}